@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthorService_CreateAuthor_FullMethodName = "/author.AuthorService/CreateAuthor"
-	AuthorService_GetAuthor_FullMethodName    = "/author.AuthorService/GetAuthor"
-	AuthorService_UpdateAuthor_FullMethodName = "/author.AuthorService/UpdateAuthor"
-	AuthorService_DeleteAuthor_FullMethodName = "/author.AuthorService/DeleteAuthor"
-	AuthorService_ListAuthors_FullMethodName  = "/author.AuthorService/ListAuthors"
+	AuthorService_CreateAuthor_FullMethodName       = "/author.AuthorService/CreateAuthor"
+	AuthorService_GetAuthor_FullMethodName          = "/author.AuthorService/GetAuthor"
+	AuthorService_UpdateAuthor_FullMethodName       = "/author.AuthorService/UpdateAuthor"
+	AuthorService_DeleteAuthor_FullMethodName       = "/author.AuthorService/DeleteAuthor"
+	AuthorService_RestoreAuthor_FullMethodName      = "/author.AuthorService/RestoreAuthor"
+	AuthorService_ListDeletedAuthors_FullMethodName = "/author.AuthorService/ListDeletedAuthors"
+	AuthorService_ListAuthors_FullMethodName        = "/author.AuthorService/ListAuthors"
 )
 
 // AuthorServiceClient is the client API for AuthorService service.
@@ -34,6 +36,8 @@ type AuthorServiceClient interface {
 	GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*AuthorResponse, error)
 	UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*AuthorResponse, error)
 	DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*DeleteAuthorResponse, error)
+	RestoreAuthor(ctx context.Context, in *RestoreAuthorRequest, opts ...grpc.CallOption) (*AuthorResponse, error)
+	ListDeletedAuthors(ctx context.Context, in *ListDeletedAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error)
 	ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error)
 }
 
@@ -85,6 +89,26 @@ func (c *authorServiceClient) DeleteAuthor(ctx context.Context, in *DeleteAuthor
 	return out, nil
 }
 
+func (c *authorServiceClient) RestoreAuthor(ctx context.Context, in *RestoreAuthorRequest, opts ...grpc.CallOption) (*AuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorResponse)
+	err := c.cc.Invoke(ctx, AuthorService_RestoreAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorServiceClient) ListDeletedAuthors(ctx context.Context, in *ListDeletedAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuthorsResponse)
+	err := c.cc.Invoke(ctx, AuthorService_ListDeletedAuthors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authorServiceClient) ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListAuthorsResponse)
@@ -103,6 +127,8 @@ type AuthorServiceServer interface {
 	GetAuthor(context.Context, *GetAuthorRequest) (*AuthorResponse, error)
 	UpdateAuthor(context.Context, *UpdateAuthorRequest) (*AuthorResponse, error)
 	DeleteAuthor(context.Context, *DeleteAuthorRequest) (*DeleteAuthorResponse, error)
+	RestoreAuthor(context.Context, *RestoreAuthorRequest) (*AuthorResponse, error)
+	ListDeletedAuthors(context.Context, *ListDeletedAuthorsRequest) (*ListAuthorsResponse, error)
 	ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error)
 	mustEmbedUnimplementedAuthorServiceServer()
 }
@@ -126,6 +152,12 @@ func (UnimplementedAuthorServiceServer) UpdateAuthor(context.Context, *UpdateAut
 func (UnimplementedAuthorServiceServer) DeleteAuthor(context.Context, *DeleteAuthorRequest) (*DeleteAuthorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthor not implemented")
 }
+func (UnimplementedAuthorServiceServer) RestoreAuthor(context.Context, *RestoreAuthorRequest) (*AuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAuthor not implemented")
+}
+func (UnimplementedAuthorServiceServer) ListDeletedAuthors(context.Context, *ListDeletedAuthorsRequest) (*ListAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedAuthors not implemented")
+}
 func (UnimplementedAuthorServiceServer) ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAuthors not implemented")
 }
@@ -222,6 +254,42 @@ func _AuthorService_DeleteAuthor_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthorService_RestoreAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).RestoreAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_RestoreAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).RestoreAuthor(ctx, req.(*RestoreAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorService_ListDeletedAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServiceServer).ListDeletedAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorService_ListDeletedAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServiceServer).ListDeletedAuthors(ctx, req.(*ListDeletedAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthorService_ListAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListAuthorsRequest)
 	if err := dec(in); err != nil {
@@ -263,6 +331,14 @@ var AuthorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAuthor",
 			Handler:    _AuthorService_DeleteAuthor_Handler,
 		},
+		{
+			MethodName: "RestoreAuthor",
+			Handler:    _AuthorService_RestoreAuthor_Handler,
+		},
+		{
+			MethodName: "ListDeletedAuthors",
+			Handler:    _AuthorService_ListDeletedAuthors_Handler,
+		},
 		{
 			MethodName: "ListAuthors",
 			Handler:    _AuthorService_ListAuthors_Handler,
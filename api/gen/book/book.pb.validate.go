@@ -0,0 +1,3305 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: book.proto
+
+package book
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// define the regex for a UUID once up-front
+var _book_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on BookSummary with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BookSummary) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BookSummary with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BookSummaryMultiError, or
+// nil if none found.
+func (m *BookSummary) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BookSummary) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Title
+
+	if all {
+		switch v := interface{}(m.GetAuthor()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "Author",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "Author",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAuthor()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookSummaryValidationError{
+				field:  "Author",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Isbn
+
+	// no validation rules for PublicationYear
+
+	// no validation rules for Publisher
+
+	// no validation rules for TotalCopies
+
+	// no validation rules for AvailableCopies
+
+	for idx, item := range m.GetCategories() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BookSummaryValidationError{
+						field:  fmt.Sprintf("Categories[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BookSummaryValidationError{
+						field:  fmt.Sprintf("Categories[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BookSummaryValidationError{
+					field:  fmt.Sprintf("Categories[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookSummaryValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookSummaryValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Version
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookSummaryValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookSummaryValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return BookSummaryMultiError(errors)
+	}
+
+	return nil
+}
+
+// BookSummaryMultiError is an error wrapping multiple validation errors
+// returned by BookSummary.ValidateAll() if the designated constraints aren't met.
+type BookSummaryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BookSummaryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BookSummaryMultiError) AllErrors() []error { return m }
+
+// BookSummaryValidationError is the validation error returned by
+// BookSummary.Validate if the designated constraints aren't met.
+type BookSummaryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BookSummaryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BookSummaryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BookSummaryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BookSummaryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BookSummaryValidationError) ErrorName() string { return "BookSummaryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BookSummaryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBookSummary.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BookSummaryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BookSummaryValidationError{}
+
+// Validate checks the field values on Book with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Book) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Book with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in BookMultiError, or nil if none found.
+func (m *Book) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Book) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Title
+
+	if all {
+		switch v := interface{}(m.GetAuthor()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "Author",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "Author",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAuthor()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookValidationError{
+				field:  "Author",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Isbn
+
+	// no validation rules for PublicationYear
+
+	// no validation rules for Publisher
+
+	// no validation rules for Description
+
+	// no validation rules for TotalCopies
+
+	// no validation rules for AvailableCopies
+
+	for idx, item := range m.GetCategories() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BookValidationError{
+						field:  fmt.Sprintf("Categories[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BookValidationError{
+						field:  fmt.Sprintf("Categories[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BookValidationError{
+					field:  fmt.Sprintf("Categories[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Version
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return BookMultiError(errors)
+	}
+
+	return nil
+}
+
+// BookMultiError is an error wrapping multiple validation errors returned by
+// Book.ValidateAll() if the designated constraints aren't met.
+type BookMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BookMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BookMultiError) AllErrors() []error { return m }
+
+// BookValidationError is the validation error returned by Book.Validate if the
+// designated constraints aren't met.
+type BookValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BookValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BookValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BookValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BookValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BookValidationError) ErrorName() string { return "BookValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BookValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBook.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BookValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BookValidationError{}
+
+// Validate checks the field values on AuthorSummary with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AuthorSummary) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuthorSummary with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AuthorSummaryMultiError, or
+// nil if none found.
+func (m *AuthorSummary) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuthorSummary) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return AuthorSummaryMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuthorSummaryMultiError is an error wrapping multiple validation errors
+// returned by AuthorSummary.ValidateAll() if the designated constraints
+// aren't met.
+type AuthorSummaryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuthorSummaryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuthorSummaryMultiError) AllErrors() []error { return m }
+
+// AuthorSummaryValidationError is the validation error returned by
+// AuthorSummary.Validate if the designated constraints aren't met.
+type AuthorSummaryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuthorSummaryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuthorSummaryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuthorSummaryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuthorSummaryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuthorSummaryValidationError) ErrorName() string { return "AuthorSummaryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuthorSummaryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuthorSummary.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuthorSummaryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuthorSummaryValidationError{}
+
+// Validate checks the field values on CategorySummary with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CategorySummary) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CategorySummary with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CategorySummaryMultiError, or nil if none found.
+func (m *CategorySummary) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CategorySummary) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return CategorySummaryMultiError(errors)
+	}
+
+	return nil
+}
+
+// CategorySummaryMultiError is an error wrapping multiple validation errors
+// returned by CategorySummary.ValidateAll() if the designated constraints
+// aren't met.
+type CategorySummaryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CategorySummaryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CategorySummaryMultiError) AllErrors() []error { return m }
+
+// CategorySummaryValidationError is the validation error returned by
+// CategorySummary.Validate if the designated constraints aren't met.
+type CategorySummaryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CategorySummaryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CategorySummaryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CategorySummaryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CategorySummaryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CategorySummaryValidationError) ErrorName() string { return "CategorySummaryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CategorySummaryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCategorySummary.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CategorySummaryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CategorySummaryValidationError{}
+
+// Validate checks the field values on CreateBookRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateBookRequestMultiError, or nil if none found.
+func (m *CreateBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetTitle()); l < 1 || l > 255 {
+		err := CreateBookRequestValidationError{
+			field:  "Title",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateUuid(m.GetAuthorId()); err != nil {
+		err = CreateBookRequestValidationError{
+			field:  "AuthorId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetIsbn()); l < 10 || l > 20 {
+		err := CreateBookRequestValidationError{
+			field:  "Isbn",
+			reason: "value length must be between 10 and 20 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetPublicationYear(); val <= 0 || val > 9999 {
+		err := CreateBookRequestValidationError{
+			field:  "PublicationYear",
+			reason: "value must be inside range (0, 9999]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetPublisher()); l < 1 || l > 255 {
+		err := CreateBookRequestValidationError{
+			field:  "Publisher",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Description
+
+	if m.GetTotalCopies() < 0 {
+		err := CreateBookRequestValidationError{
+			field:  "TotalCopies",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_CreateBookRequest_CategoryIds_Unique := make(map[string]struct{}, len(m.GetCategoryIds()))
+
+	for idx, item := range m.GetCategoryIds() {
+		_, _ = idx, item
+
+		if _, exists := _CreateBookRequest_CategoryIds_Unique[item]; exists {
+			err := CreateBookRequestValidationError{
+				field:  fmt.Sprintf("CategoryIds[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_CreateBookRequest_CategoryIds_Unique[item] = struct{}{}
+		}
+
+		if err := m._validateUuid(item); err != nil {
+			err = CreateBookRequestValidationError{
+				field:  fmt.Sprintf("CategoryIds[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *CreateBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// CreateBookRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateBookRequestMultiError) AllErrors() []error { return m }
+
+// CreateBookRequestValidationError is the validation error returned by
+// CreateBookRequest.Validate if the designated constraints aren't met.
+type CreateBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateBookRequestValidationError) ErrorName() string {
+	return "CreateBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateBookRequestValidationError{}
+
+// Validate checks the field values on GetBookRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetBookRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetBookRequestMultiError,
+// or nil if none found.
+func (m *GetBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = GetBookRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// GetBookRequestMultiError is an error wrapping multiple validation errors
+// returned by GetBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetBookRequestMultiError) AllErrors() []error { return m }
+
+// GetBookRequestValidationError is the validation error returned by
+// GetBookRequest.Validate if the designated constraints aren't met.
+type GetBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetBookRequestValidationError) ErrorName() string { return "GetBookRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetBookRequestValidationError{}
+
+// Validate checks the field values on UpdateBookRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateBookRequestMultiError, or nil if none found.
+func (m *UpdateBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = UpdateBookRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetTitle()); l < 1 || l > 255 {
+		err := UpdateBookRequestValidationError{
+			field:  "Title",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateUuid(m.GetAuthorId()); err != nil {
+		err = UpdateBookRequestValidationError{
+			field:  "AuthorId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetIsbn()); l < 10 || l > 20 {
+		err := UpdateBookRequestValidationError{
+			field:  "Isbn",
+			reason: "value length must be between 10 and 20 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetPublicationYear(); val <= 0 || val > 9999 {
+		err := UpdateBookRequestValidationError{
+			field:  "PublicationYear",
+			reason: "value must be inside range (0, 9999]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetPublisher()); l < 1 || l > 255 {
+		err := UpdateBookRequestValidationError{
+			field:  "Publisher",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Description
+
+	if m.GetTotalCopies() < 0 {
+		err := UpdateBookRequestValidationError{
+			field:  "TotalCopies",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_UpdateBookRequest_CategoryIds_Unique := make(map[string]struct{}, len(m.GetCategoryIds()))
+
+	for idx, item := range m.GetCategoryIds() {
+		_, _ = idx, item
+
+		if _, exists := _UpdateBookRequest_CategoryIds_Unique[item]; exists {
+			err := UpdateBookRequestValidationError{
+				field:  fmt.Sprintf("CategoryIds[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_UpdateBookRequest_CategoryIds_Unique[item] = struct{}{}
+		}
+
+		if err := m._validateUuid(item); err != nil {
+			err = UpdateBookRequestValidationError{
+				field:  fmt.Sprintf("CategoryIds[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetVersion() < 0 {
+		err := UpdateBookRequestValidationError{
+			field:  "Version",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdateMask()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateBookRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateBookRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdateMask()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateBookRequestValidationError{
+				field:  "UpdateMask",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *UpdateBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// UpdateBookRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateBookRequestMultiError) AllErrors() []error { return m }
+
+// UpdateBookRequestValidationError is the validation error returned by
+// UpdateBookRequest.Validate if the designated constraints aren't met.
+type UpdateBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateBookRequestValidationError) ErrorName() string {
+	return "UpdateBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateBookRequestValidationError{}
+
+// Validate checks the field values on DeleteBookRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteBookRequestMultiError, or nil if none found.
+func (m *DeleteBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = DeleteBookRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *DeleteBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// DeleteBookRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteBookRequestMultiError) AllErrors() []error { return m }
+
+// DeleteBookRequestValidationError is the validation error returned by
+// DeleteBookRequest.Validate if the designated constraints aren't met.
+type DeleteBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteBookRequestValidationError) ErrorName() string {
+	return "DeleteBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteBookRequestValidationError{}
+
+// Validate checks the field values on DeleteBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteBookResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteBookResponseMultiError, or nil if none found.
+func (m *DeleteBookResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteBookResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return DeleteBookResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteBookResponseMultiError is an error wrapping multiple validation errors
+// returned by DeleteBookResponse.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteBookResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteBookResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteBookResponseMultiError) AllErrors() []error { return m }
+
+// DeleteBookResponseValidationError is the validation error returned by
+// DeleteBookResponse.Validate if the designated constraints aren't met.
+type DeleteBookResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteBookResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteBookResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteBookResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteBookResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteBookResponseValidationError) ErrorName() string {
+	return "DeleteBookResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteBookResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteBookResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteBookResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteBookResponseValidationError{}
+
+// Validate checks the field values on ListReferencedAuthorsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListReferencedAuthorsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListReferencedAuthorsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListReferencedAuthorsRequestMultiError, or nil if none found.
+func (m *ListReferencedAuthorsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListReferencedAuthorsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAuthorIds()) > 1000 {
+		err := ListReferencedAuthorsRequestValidationError{
+			field:  "AuthorIds",
+			reason: "value must contain no more than 1000 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetAuthorIds() {
+		_, _ = idx, item
+
+		if err := m._validateUuid(item); err != nil {
+			err = ListReferencedAuthorsRequestValidationError{
+				field:  fmt.Sprintf("AuthorIds[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListReferencedAuthorsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ListReferencedAuthorsRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ListReferencedAuthorsRequestMultiError is an error wrapping multiple
+// validation errors returned by ListReferencedAuthorsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ListReferencedAuthorsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListReferencedAuthorsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListReferencedAuthorsRequestMultiError) AllErrors() []error { return m }
+
+// ListReferencedAuthorsRequestValidationError is the validation error returned
+// by ListReferencedAuthorsRequest.Validate if the designated constraints
+// aren't met.
+type ListReferencedAuthorsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListReferencedAuthorsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListReferencedAuthorsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListReferencedAuthorsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListReferencedAuthorsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListReferencedAuthorsRequestValidationError) ErrorName() string {
+	return "ListReferencedAuthorsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListReferencedAuthorsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListReferencedAuthorsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListReferencedAuthorsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListReferencedAuthorsRequestValidationError{}
+
+// Validate checks the field values on ListReferencedAuthorsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListReferencedAuthorsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListReferencedAuthorsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListReferencedAuthorsResponseMultiError, or nil if none found.
+func (m *ListReferencedAuthorsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListReferencedAuthorsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListReferencedAuthorsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListReferencedAuthorsResponseMultiError is an error wrapping multiple
+// validation errors returned by ListReferencedAuthorsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListReferencedAuthorsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListReferencedAuthorsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListReferencedAuthorsResponseMultiError) AllErrors() []error { return m }
+
+// ListReferencedAuthorsResponseValidationError is the validation error
+// returned by ListReferencedAuthorsResponse.Validate if the designated
+// constraints aren't met.
+type ListReferencedAuthorsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListReferencedAuthorsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListReferencedAuthorsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListReferencedAuthorsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListReferencedAuthorsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListReferencedAuthorsResponseValidationError) ErrorName() string {
+	return "ListReferencedAuthorsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListReferencedAuthorsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListReferencedAuthorsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListReferencedAuthorsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListReferencedAuthorsResponseValidationError{}
+
+// Validate checks the field values on RestoreBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreBookRequestMultiError, or nil if none found.
+func (m *RestoreBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = RestoreBookRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RestoreBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RestoreBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// RestoreBookRequestMultiError is an error wrapping multiple validation errors
+// returned by RestoreBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RestoreBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreBookRequestMultiError) AllErrors() []error { return m }
+
+// RestoreBookRequestValidationError is the validation error returned by
+// RestoreBookRequest.Validate if the designated constraints aren't met.
+type RestoreBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreBookRequestValidationError) ErrorName() string {
+	return "RestoreBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreBookRequestValidationError{}
+
+// Validate checks the field values on ListDeletedBooksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDeletedBooksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDeletedBooksRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDeletedBooksRequestMultiError, or nil if none found.
+func (m *ListDeletedBooksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDeletedBooksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetPage() < 0 {
+		err := ListDeletedBooksRequestValidationError{
+			field:  "Page",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := ListDeletedBooksRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListDeletedBooksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDeletedBooksRequestMultiError is an error wrapping multiple validation
+// errors returned by ListDeletedBooksRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListDeletedBooksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDeletedBooksRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDeletedBooksRequestMultiError) AllErrors() []error { return m }
+
+// ListDeletedBooksRequestValidationError is the validation error returned by
+// ListDeletedBooksRequest.Validate if the designated constraints aren't met.
+type ListDeletedBooksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDeletedBooksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDeletedBooksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDeletedBooksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDeletedBooksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDeletedBooksRequestValidationError) ErrorName() string {
+	return "ListDeletedBooksRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDeletedBooksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDeletedBooksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDeletedBooksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDeletedBooksRequestValidationError{}
+
+// Validate checks the field values on ListBooksRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListBooksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListBooksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListBooksRequestMultiError, or nil if none found.
+func (m *ListBooksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListBooksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetPage() < 0 {
+		err := ListBooksRequestValidationError{
+			field:  "Page",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 100 {
+		err := ListBooksRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 100]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for TitleQuery
+
+	// no validation rules for AuthorQuery
+
+	for idx, item := range m.GetCategoryIds() {
+		_, _ = idx, item
+
+		if err := m._validateUuid(item); err != nil {
+			err = ListBooksRequestValidationError{
+				field:  fmt.Sprintf("CategoryIds[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for IsbnQuery
+
+	if m.GetPublicationYearStart() < 0 {
+		err := ListBooksRequestValidationError{
+			field:  "PublicationYearStart",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPublicationYearEnd() < 0 {
+		err := ListBooksRequestValidationError{
+			field:  "PublicationYearEnd",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PublisherQuery
+
+	// no validation rules for AvailableOnly
+
+	if _, ok := ListBooksRequest_SortBy_name[int32(m.GetSortBy())]; !ok {
+		err := ListBooksRequestValidationError{
+			field:  "SortBy",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for SortDesc
+
+	if len(errors) > 0 {
+		return ListBooksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ListBooksRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ListBooksRequestMultiError is an error wrapping multiple validation errors
+// returned by ListBooksRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListBooksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListBooksRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListBooksRequestMultiError) AllErrors() []error { return m }
+
+// ListBooksRequestValidationError is the validation error returned by
+// ListBooksRequest.Validate if the designated constraints aren't met.
+type ListBooksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListBooksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListBooksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListBooksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListBooksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListBooksRequestValidationError) ErrorName() string { return "ListBooksRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListBooksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListBooksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListBooksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListBooksRequestValidationError{}
+
+// Validate checks the field values on ListBooksResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListBooksResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListBooksResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListBooksResponseMultiError, or nil if none found.
+func (m *ListBooksResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListBooksResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetBooks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListBooksResponseValidationError{
+						field:  fmt.Sprintf("Books[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListBooksResponseValidationError{
+						field:  fmt.Sprintf("Books[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListBooksResponseValidationError{
+					field:  fmt.Sprintf("Books[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListBooksResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListBooksResponseMultiError is an error wrapping multiple validation errors
+// returned by ListBooksResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListBooksResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListBooksResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListBooksResponseMultiError) AllErrors() []error { return m }
+
+// ListBooksResponseValidationError is the validation error returned by
+// ListBooksResponse.Validate if the designated constraints aren't met.
+type ListBooksResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListBooksResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListBooksResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListBooksResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListBooksResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListBooksResponseValidationError) ErrorName() string {
+	return "ListBooksResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListBooksResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListBooksResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListBooksResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListBooksResponseValidationError{}
+
+// Validate checks the field values on BookResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BookResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BookResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BookResponseMultiError, or
+// nil if none found.
+func (m *BookResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BookResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetBook()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BookResponseValidationError{
+					field:  "Book",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BookResponseValidationError{
+					field:  "Book",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBook()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BookResponseValidationError{
+				field:  "Book",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return BookResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BookResponseMultiError is an error wrapping multiple validation errors
+// returned by BookResponse.ValidateAll() if the designated constraints aren't met.
+type BookResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BookResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BookResponseMultiError) AllErrors() []error { return m }
+
+// BookResponseValidationError is the validation error returned by
+// BookResponse.Validate if the designated constraints aren't met.
+type BookResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BookResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BookResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BookResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BookResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BookResponseValidationError) ErrorName() string { return "BookResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BookResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBookResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BookResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BookResponseValidationError{}
+
+// Validate checks the field values on BorrowBookRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BorrowBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BorrowBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BorrowBookRequestMultiError, or nil if none found.
+func (m *BorrowBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BorrowBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = BorrowBookRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = BorrowBookRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return BorrowBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *BorrowBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// BorrowBookRequestMultiError is an error wrapping multiple validation errors
+// returned by BorrowBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type BorrowBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BorrowBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BorrowBookRequestMultiError) AllErrors() []error { return m }
+
+// BorrowBookRequestValidationError is the validation error returned by
+// BorrowBookRequest.Validate if the designated constraints aren't met.
+type BorrowBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BorrowBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BorrowBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BorrowBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BorrowBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BorrowBookRequestValidationError) ErrorName() string {
+	return "BorrowBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BorrowBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBorrowBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BorrowBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BorrowBookRequestValidationError{}
+
+// Validate checks the field values on BorrowBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BorrowBookResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BorrowBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BorrowBookResponseMultiError, or nil if none found.
+func (m *BorrowBookResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BorrowBookResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	// no validation rules for TransactionId
+
+	if len(errors) > 0 {
+		return BorrowBookResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BorrowBookResponseMultiError is an error wrapping multiple validation errors
+// returned by BorrowBookResponse.ValidateAll() if the designated constraints
+// aren't met.
+type BorrowBookResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BorrowBookResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BorrowBookResponseMultiError) AllErrors() []error { return m }
+
+// BorrowBookResponseValidationError is the validation error returned by
+// BorrowBookResponse.Validate if the designated constraints aren't met.
+type BorrowBookResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BorrowBookResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BorrowBookResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BorrowBookResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BorrowBookResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BorrowBookResponseValidationError) ErrorName() string {
+	return "BorrowBookResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BorrowBookResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBorrowBookResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BorrowBookResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BorrowBookResponseValidationError{}
+
+// Validate checks the field values on ReturnBookRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReturnBookRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReturnBookRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReturnBookRequestMultiError, or nil if none found.
+func (m *ReturnBookRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReturnBookRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = ReturnBookRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateUuid(m.GetUserId()); err != nil {
+		err = ReturnBookRequestValidationError{
+			field:  "UserId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateUuid(m.GetTransactionId()); err != nil {
+		err = ReturnBookRequestValidationError{
+			field:  "TransactionId",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReturnBookRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ReturnBookRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ReturnBookRequestMultiError is an error wrapping multiple validation errors
+// returned by ReturnBookRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ReturnBookRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReturnBookRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReturnBookRequestMultiError) AllErrors() []error { return m }
+
+// ReturnBookRequestValidationError is the validation error returned by
+// ReturnBookRequest.Validate if the designated constraints aren't met.
+type ReturnBookRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReturnBookRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReturnBookRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReturnBookRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReturnBookRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReturnBookRequestValidationError) ErrorName() string {
+	return "ReturnBookRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReturnBookRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReturnBookRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReturnBookRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReturnBookRequestValidationError{}
+
+// Validate checks the field values on ReturnBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReturnBookResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReturnBookResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReturnBookResponseMultiError, or nil if none found.
+func (m *ReturnBookResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReturnBookResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return ReturnBookResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReturnBookResponseMultiError is an error wrapping multiple validation errors
+// returned by ReturnBookResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ReturnBookResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReturnBookResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReturnBookResponseMultiError) AllErrors() []error { return m }
+
+// ReturnBookResponseValidationError is the validation error returned by
+// ReturnBookResponse.Validate if the designated constraints aren't met.
+type ReturnBookResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReturnBookResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReturnBookResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReturnBookResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReturnBookResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReturnBookResponseValidationError) ErrorName() string {
+	return "ReturnBookResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReturnBookResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReturnBookResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReturnBookResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReturnBookResponseValidationError{}
+
+// Validate checks the field values on GetBookRecommendationsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetBookRecommendationsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetBookRecommendationsRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetBookRecommendationsRequestMultiError, or nil if none found.
+func (m *GetBookRecommendationsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetBookRecommendationsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = GetBookRecommendationsRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val < 0 || val > 50 {
+		err := GetBookRecommendationsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 50]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetBookRecommendationsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetBookRecommendationsRequest) _validateUuid(uuid string) error {
+	if matched := _book_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// GetBookRecommendationsRequestMultiError is an error wrapping multiple
+// validation errors returned by GetBookRecommendationsRequest.ValidateAll()
+// if the designated constraints aren't met.
+type GetBookRecommendationsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetBookRecommendationsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetBookRecommendationsRequestMultiError) AllErrors() []error { return m }
+
+// GetBookRecommendationsRequestValidationError is the validation error
+// returned by GetBookRecommendationsRequest.Validate if the designated
+// constraints aren't met.
+type GetBookRecommendationsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetBookRecommendationsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetBookRecommendationsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetBookRecommendationsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetBookRecommendationsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetBookRecommendationsRequestValidationError) ErrorName() string {
+	return "GetBookRecommendationsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetBookRecommendationsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetBookRecommendationsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetBookRecommendationsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetBookRecommendationsRequestValidationError{}
+
+// Validate checks the field values on GetBookRecommendationsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetBookRecommendationsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetBookRecommendationsResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetBookRecommendationsResponseMultiError, or nil if none found.
+func (m *GetBookRecommendationsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetBookRecommendationsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRecommendations() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetBookRecommendationsResponseValidationError{
+						field:  fmt.Sprintf("Recommendations[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetBookRecommendationsResponseValidationError{
+						field:  fmt.Sprintf("Recommendations[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetBookRecommendationsResponseValidationError{
+					field:  fmt.Sprintf("Recommendations[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetBookRecommendationsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetBookRecommendationsResponseMultiError is an error wrapping multiple
+// validation errors returned by GetBookRecommendationsResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetBookRecommendationsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetBookRecommendationsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetBookRecommendationsResponseMultiError) AllErrors() []error { return m }
+
+// GetBookRecommendationsResponseValidationError is the validation error
+// returned by GetBookRecommendationsResponse.Validate if the designated
+// constraints aren't met.
+type GetBookRecommendationsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetBookRecommendationsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetBookRecommendationsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetBookRecommendationsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetBookRecommendationsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetBookRecommendationsResponseValidationError) ErrorName() string {
+	return "GetBookRecommendationsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetBookRecommendationsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetBookRecommendationsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetBookRecommendationsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetBookRecommendationsResponseValidationError{}
@@ -23,10 +23,13 @@ const (
 	BookService_GetBook_FullMethodName                = "/book.BookService/GetBook"
 	BookService_UpdateBook_FullMethodName             = "/book.BookService/UpdateBook"
 	BookService_DeleteBook_FullMethodName             = "/book.BookService/DeleteBook"
+	BookService_RestoreBook_FullMethodName            = "/book.BookService/RestoreBook"
+	BookService_ListDeletedBooks_FullMethodName       = "/book.BookService/ListDeletedBooks"
 	BookService_ListBooks_FullMethodName              = "/book.BookService/ListBooks"
 	BookService_BorrowBook_FullMethodName             = "/book.BookService/BorrowBook"
 	BookService_ReturnBook_FullMethodName             = "/book.BookService/ReturnBook"
 	BookService_GetBookRecommendations_FullMethodName = "/book.BookService/GetBookRecommendations"
+	BookService_ListReferencedAuthors_FullMethodName  = "/book.BookService/ListReferencedAuthors"
 )
 
 // BookServiceClient is the client API for BookService service.
@@ -37,10 +40,15 @@ type BookServiceClient interface {
 	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
 	UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
 	DeleteBook(ctx context.Context, in *DeleteBookRequest, opts ...grpc.CallOption) (*DeleteBookResponse, error)
+	RestoreBook(ctx context.Context, in *RestoreBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
+	ListDeletedBooks(ctx context.Context, in *ListDeletedBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
 	ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
 	BorrowBook(ctx context.Context, in *BorrowBookRequest, opts ...grpc.CallOption) (*BorrowBookResponse, error)
 	ReturnBook(ctx context.Context, in *ReturnBookRequest, opts ...grpc.CallOption) (*ReturnBookResponse, error)
 	GetBookRecommendations(ctx context.Context, in *GetBookRecommendationsRequest, opts ...grpc.CallOption) (*GetBookRecommendationsResponse, error)
+	// ListReferencedAuthors returns the authors that books refer to, deleted books included. The
+	// author service calls it before purging deleted authors, it has no HTTP route.
+	ListReferencedAuthors(ctx context.Context, in *ListReferencedAuthorsRequest, opts ...grpc.CallOption) (*ListReferencedAuthorsResponse, error)
 }
 
 type bookServiceClient struct {
@@ -91,6 +99,26 @@ func (c *bookServiceClient) DeleteBook(ctx context.Context, in *DeleteBookReques
 	return out, nil
 }
 
+func (c *bookServiceClient) RestoreBook(ctx context.Context, in *RestoreBookRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, BookService_RestoreBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListDeletedBooks(ctx context.Context, in *ListDeletedBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBooksResponse)
+	err := c.cc.Invoke(ctx, BookService_ListDeletedBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bookServiceClient) ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListBooksResponse)
@@ -131,6 +159,16 @@ func (c *bookServiceClient) GetBookRecommendations(ctx context.Context, in *GetB
 	return out, nil
 }
 
+func (c *bookServiceClient) ListReferencedAuthors(ctx context.Context, in *ListReferencedAuthorsRequest, opts ...grpc.CallOption) (*ListReferencedAuthorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReferencedAuthorsResponse)
+	err := c.cc.Invoke(ctx, BookService_ListReferencedAuthors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BookServiceServer is the server API for BookService service.
 // All implementations must embed UnimplementedBookServiceServer
 // for forward compatibility.
@@ -139,10 +177,15 @@ type BookServiceServer interface {
 	GetBook(context.Context, *GetBookRequest) (*BookResponse, error)
 	UpdateBook(context.Context, *UpdateBookRequest) (*BookResponse, error)
 	DeleteBook(context.Context, *DeleteBookRequest) (*DeleteBookResponse, error)
+	RestoreBook(context.Context, *RestoreBookRequest) (*BookResponse, error)
+	ListDeletedBooks(context.Context, *ListDeletedBooksRequest) (*ListBooksResponse, error)
 	ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error)
 	BorrowBook(context.Context, *BorrowBookRequest) (*BorrowBookResponse, error)
 	ReturnBook(context.Context, *ReturnBookRequest) (*ReturnBookResponse, error)
 	GetBookRecommendations(context.Context, *GetBookRecommendationsRequest) (*GetBookRecommendationsResponse, error)
+	// ListReferencedAuthors returns the authors that books refer to, deleted books included. The
+	// author service calls it before purging deleted authors, it has no HTTP route.
+	ListReferencedAuthors(context.Context, *ListReferencedAuthorsRequest) (*ListReferencedAuthorsResponse, error)
 	mustEmbedUnimplementedBookServiceServer()
 }
 
@@ -165,6 +208,12 @@ func (UnimplementedBookServiceServer) UpdateBook(context.Context, *UpdateBookReq
 func (UnimplementedBookServiceServer) DeleteBook(context.Context, *DeleteBookRequest) (*DeleteBookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBook not implemented")
 }
+func (UnimplementedBookServiceServer) RestoreBook(context.Context, *RestoreBookRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBook not implemented")
+}
+func (UnimplementedBookServiceServer) ListDeletedBooks(context.Context, *ListDeletedBooksRequest) (*ListBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedBooks not implemented")
+}
 func (UnimplementedBookServiceServer) ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBooks not implemented")
 }
@@ -177,6 +226,9 @@ func (UnimplementedBookServiceServer) ReturnBook(context.Context, *ReturnBookReq
 func (UnimplementedBookServiceServer) GetBookRecommendations(context.Context, *GetBookRecommendationsRequest) (*GetBookRecommendationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBookRecommendations not implemented")
 }
+func (UnimplementedBookServiceServer) ListReferencedAuthors(context.Context, *ListReferencedAuthorsRequest) (*ListReferencedAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReferencedAuthors not implemented")
+}
 func (UnimplementedBookServiceServer) mustEmbedUnimplementedBookServiceServer() {}
 func (UnimplementedBookServiceServer) testEmbeddedByValue()                     {}
 
@@ -270,6 +322,42 @@ func _BookService_DeleteBook_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_RestoreBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RestoreBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_RestoreBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RestoreBook(ctx, req.(*RestoreBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListDeletedBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListDeletedBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListDeletedBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListDeletedBooks(ctx, req.(*ListDeletedBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BookService_ListBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListBooksRequest)
 	if err := dec(in); err != nil {
@@ -342,6 +430,24 @@ func _BookService_GetBookRecommendations_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_ListReferencedAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReferencedAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListReferencedAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListReferencedAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListReferencedAuthors(ctx, req.(*ListReferencedAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BookService_ServiceDesc is the grpc.ServiceDesc for BookService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -365,6 +471,14 @@ var BookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBook",
 			Handler:    _BookService_DeleteBook_Handler,
 		},
+		{
+			MethodName: "RestoreBook",
+			Handler:    _BookService_RestoreBook_Handler,
+		},
+		{
+			MethodName: "ListDeletedBooks",
+			Handler:    _BookService_ListDeletedBooks_Handler,
+		},
 		{
 			MethodName: "ListBooks",
 			Handler:    _BookService_ListBooks_Handler,
@@ -381,6 +495,10 @@ var BookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBookRecommendations",
 			Handler:    _BookService_GetBookRecommendations_Handler,
 		},
+		{
+			MethodName: "ListReferencedAuthors",
+			Handler:    _BookService_ListReferencedAuthors_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "book.proto",
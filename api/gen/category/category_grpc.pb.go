@@ -23,6 +23,8 @@ const (
 	CategoryService_GetCategory_FullMethodName             = "/category.CategoryService/GetCategory"
 	CategoryService_UpdateCategory_FullMethodName          = "/category.CategoryService/UpdateCategory"
 	CategoryService_DeleteCategory_FullMethodName          = "/category.CategoryService/DeleteCategory"
+	CategoryService_RestoreCategory_FullMethodName         = "/category.CategoryService/RestoreCategory"
+	CategoryService_ListDeletedCategories_FullMethodName   = "/category.CategoryService/ListDeletedCategories"
 	CategoryService_ListCategories_FullMethodName          = "/category.CategoryService/ListCategories"
 	CategoryService_UpdateItemCategories_FullMethodName    = "/category.CategoryService/UpdateItemCategories"
 	CategoryService_BulkAddItemToCategories_FullMethodName = "/category.CategoryService/BulkAddItemToCategories"
@@ -38,6 +40,8 @@ type CategoryServiceClient interface {
 	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+	RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
+	ListDeletedCategories(ctx context.Context, in *ListDeletedCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
 	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
 	UpdateItemCategories(ctx context.Context, in *UpdateItemCategoriesRequest, opts ...grpc.CallOption) (*UpdateItemCategoriesResponse, error)
 	BulkAddItemToCategories(ctx context.Context, in *BulkAddItemToCategoriesRequest, opts ...grpc.CallOption) (*BulkAddItemToCategoriesResponse, error)
@@ -93,6 +97,26 @@ func (c *categoryServiceClient) DeleteCategory(ctx context.Context, in *DeleteCa
 	return out, nil
 }
 
+func (c *categoryServiceClient) RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_RestoreCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) ListDeletedCategories(ctx context.Context, in *ListDeletedCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoryService_ListDeletedCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *categoryServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListCategoriesResponse)
@@ -151,6 +175,8 @@ type CategoryServiceServer interface {
 	GetCategory(context.Context, *GetCategoryRequest) (*CategoryResponse, error)
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*CategoryResponse, error)
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
+	RestoreCategory(context.Context, *RestoreCategoryRequest) (*CategoryResponse, error)
+	ListDeletedCategories(context.Context, *ListDeletedCategoriesRequest) (*ListCategoriesResponse, error)
 	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
 	UpdateItemCategories(context.Context, *UpdateItemCategoriesRequest) (*UpdateItemCategoriesResponse, error)
 	BulkAddItemToCategories(context.Context, *BulkAddItemToCategoriesRequest) (*BulkAddItemToCategoriesResponse, error)
@@ -178,6 +204,12 @@ func (UnimplementedCategoryServiceServer) UpdateCategory(context.Context, *Updat
 func (UnimplementedCategoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
 }
+func (UnimplementedCategoryServiceServer) RestoreCategory(context.Context, *RestoreCategoryRequest) (*CategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) ListDeletedCategories(context.Context, *ListDeletedCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedCategories not implemented")
+}
 func (UnimplementedCategoryServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
 }
@@ -286,6 +318,42 @@ func _CategoryService_DeleteCategory_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_RestoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_RestoreCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, req.(*RestoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_ListDeletedCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).ListDeletedCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_ListDeletedCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).ListDeletedCategories(ctx, req.(*ListDeletedCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CategoryService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListCategoriesRequest)
 	if err := dec(in); err != nil {
@@ -399,6 +467,14 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCategory",
 			Handler:    _CategoryService_DeleteCategory_Handler,
 		},
+		{
+			MethodName: "RestoreCategory",
+			Handler:    _CategoryService_RestoreCategory_Handler,
+		},
+		{
+			MethodName: "ListDeletedCategories",
+			Handler:    _CategoryService_ListDeletedCategories_Handler,
+		},
 		{
 			MethodName: "ListCategories",
 			Handler:    _CategoryService_ListCategories_Handler,
@@ -0,0 +1,301 @@
+// mockidp is an OpenID Connect provider for development and testing of single sign-on. It
+// signs in whoever asks with the email, name and username entered on its login page, or
+// straight away with the email of a login_hint, and checks client credentials, redirect URIs
+// and PKCE like a real provider would.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/joho/godotenv"
+	"github.com/purnasatria/library-management/pkg/env"
+	"github.com/purnasatria/library-management/pkg/jwt"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var addr = flag.String("addr", ":9000", "Address to listen on")
+
+const (
+	keyID   = "mock"
+	codeTTL = time.Minute
+)
+
+// authorization is an issued authorization code waiting to be exchanged
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	identity      identity
+	expiresAt     time.Time
+}
+
+type identity struct {
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string
+}
+
+type provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authorization
+}
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Mock identity provider</title></head>
+<body>
+<h1>Mock identity provider</h1>
+<form method="post">
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}<p><label>Email <input name="email" type="email" required></label></p>
+<p><label><input name="email_verified" type="checkbox" value="true" checked> Email verified</label></p>
+<p><label>Name <input name="name"></label></p>
+<p><label>Username <input name="preferred_username"></label></p>
+<p><button type="submit">Sign in</button></p>
+</form>
+</body>
+</html>
+`))
+
+func main() {
+	// INFO: Set up the logger
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	// INFO: setup flag
+	flag.Parse()
+
+	// INFO: setup env
+	if err := godotenv.Load(); err != nil {
+		log.Warn().Err(err).Msg("can't load config file, load defaults")
+	}
+
+	// INFO: A new signing key on every start, relying parties fetch it from the JWKS
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to generate signing key")
+	}
+
+	p := &provider{
+		issuer:       strings.TrimSuffix(env.Get("MOCK_IDP_ISSUER", "http://localhost:9000"), "/"),
+		clientID:     env.Get("OIDC_CLIENT_ID", "library"),
+		clientSecret: env.Get("OIDC_CLIENT_SECRET", "library-secret"),
+		key:          key,
+		codes:        make(map[string]*authorization),
+	}
+
+	keys, err := jwt.New(&jwt.Config{
+		Keys: map[string]*jwt.Key{
+			keyID: {ID: keyID, Method: gojwt.SigningMethodRS256, Private: key, Public: &key.PublicKey},
+		},
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up JWKS")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.Handle("/jwks", keys.JWKSHandler())
+
+	log.Info().Str("addr", *addr).Str("issuer", p.issuer).Str("client_id", p.clientID).Msg("Mock identity provider listening")
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatal().Err(err).Msg("Failed to serve")
+	}
+}
+
+func (p *provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+	})
+}
+
+// authorize shows the login page, or signs in the email of a login_hint, and redirects back
+// with a code
+func (p *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	params := r.Form
+	if params.Get("response_type") != "code" || params.Get("client_id") != p.clientID {
+		http.Error(w, "unsupported response type or unknown client", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(params.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if params.Get("code_challenge_method") != "S256" || params.Get("code_challenge") == "" {
+		http.Error(w, "PKCE with S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	email := params.Get("email")
+	if email == "" {
+		email = params.Get("login_hint")
+	}
+	if email == "" {
+		hidden := map[string]string{}
+		for _, name := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			hidden[name] = params.Get(name)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		loginPage.Execute(w, map[string]interface{}{"Params": hidden})
+		return
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = &authorization{
+		clientID:      p.clientID,
+		redirectURI:   redirectURI.String(),
+		codeChallenge: params.Get("code_challenge"),
+		nonce:         params.Get("nonce"),
+		identity: identity{
+			Email:         email,
+			EmailVerified: r.Method == http.MethodGet || params.Get("email_verified") == "true",
+			Name:          params.Get("name"),
+			Username:      params.Get("preferred_username"),
+		},
+		expiresAt: time.Now().Add(codeTTL),
+	}
+	p.mu.Unlock()
+
+	query := redirectURI.Query()
+	query.Set("code", code)
+	query.Set("state", params.Get("state"))
+	redirectURI.RawQuery = query.Encode()
+
+	log.Info().Str("email", email).Msg("Signed in")
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// token exchanges a code for an ID token
+func (p *provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		tokenError(w, http.StatusMethodNotAllowed, "invalid_request")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) != 1 {
+		tokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	// A code works once
+	p.mu.Lock()
+	auth, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(auth.expiresAt) || auth.clientID != clientID || auth.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != auth.codeChallenge {
+		tokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	claims := gojwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            "mock|" + strings.ToLower(auth.identity.Email),
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          auth.identity.Email,
+		"email_verified": auth.identity.EmailVerified,
+	}
+	if auth.nonce != "" {
+		claims["nonce"] = auth.nonce
+	}
+	if auth.identity.Name != "" {
+		claims["name"] = auth.identity.Name
+	}
+	if auth.identity.Username != "" {
+		claims["preferred_username"] = auth.identity.Username
+	}
+
+	idToken := gojwt.NewWithClaims(gojwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func tokenError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,218 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
+)
+
+// Method describes how calls to a mutating RPC are audited
+type Method struct {
+	Action     string
+	EntityType string
+	// EntityID returns the ID of the affected entity. It is called with a nil resp before the
+	// call and, if that returns nothing (e.g. creates), again with the response.
+	EntityID func(req, resp interface{}) string
+	// Snapshot returns the current state of the entity, compared before and after the call
+	Snapshot func(ctx context.Context, id string) (proto.Message, error)
+}
+
+type change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// IDFromRequest is the Method.EntityID of RPCs whose request carries the entity ID as id
+func IDFromRequest(req, resp interface{}) string {
+	if r, ok := req.(interface{ GetId() string }); ok {
+		return r.GetId()
+	}
+	return ""
+}
+
+// Interceptor creates a server-side interceptor that records an audit event for every
+// successful call to one of methods, keyed by full method name. Failing to record an event
+// is logged but does not fail the call, which has already been applied.
+func Interceptor(repo *Repository, methods map[string]Method) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		id := method.EntityID(req, nil)
+		before := snapshot(ctx, method, id)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		if id == "" {
+			id = method.EntityID(req, resp)
+		}
+		record(ctx, repo, method, info.FullMethod, id, before)
+
+		return resp, nil
+	}
+}
+
+// StreamInterceptor is the Interceptor of streaming calls. A call records one event, for the
+// entity named by the first message the client sends or, for creates, the last one the
+// server sends.
+func StreamInterceptor(repo *Repository, methods map[string]Method) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		method, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		stream := &auditedStream{ServerStream: ss, method: method}
+		if err := handler(srv, stream); err != nil {
+			return err
+		}
+
+		id := stream.id
+		if id == "" && stream.req != nil {
+			id = method.EntityID(stream.req, stream.resp)
+		}
+		record(ss.Context(), repo, method, info.FullMethod, id, stream.before)
+
+		return nil
+	}
+}
+
+// auditedStream snapshots the entity of the first message received before the handler sees
+// it, and keeps the last message sent
+type auditedStream struct {
+	grpc.ServerStream
+	method Method
+	req    interface{}
+	resp   interface{}
+	id     string
+	before proto.Message
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if s.req == nil {
+		s.req = m
+		s.id = s.method.EntityID(m, nil)
+		s.before = snapshot(s.Context(), s.method, s.id)
+	}
+	return nil
+}
+
+func (s *auditedStream) SendMsg(m interface{}) error {
+	s.resp = m
+	return s.ServerStream.SendMsg(m)
+}
+
+// record stores the audit event of a successful call, with the changes between before and
+// the entity's current state
+func record(ctx context.Context, repo *Repository, method Method, fullMethod, id string, before proto.Message) {
+	after := snapshot(ctx, method, id)
+
+	changes, err := diff(before, after)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("method", fullMethod).Msg("Failed to diff audited entity")
+		return
+	}
+
+	user, _ := grpcprotocol.UserFromContext(ctx)
+	requestID := grpcprotocol.RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	event := &Event{
+		ActorID:    user.ID,
+		ActorRole:  user.Role,
+		Action:     method.Action,
+		EntityType: method.EntityType,
+		EntityID:   id,
+		Method:     fullMethod,
+		RequestID:  requestID,
+		Changes:    changes,
+	}
+
+	// The call has been applied even if the client goes away, so is its audit event
+	if err := repo.RecordEvent(context.WithoutCancel(ctx), event); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("method", fullMethod).Str("entity_id", id).Msg("Failed to record audit event")
+	}
+}
+
+// snapshot returns nil for entities that do not exist (yet, or anymore)
+func snapshot(ctx context.Context, method Method, id string) proto.Message {
+	if id == "" {
+		return nil
+	}
+
+	m, err := method.Snapshot(ctx, id)
+	if err != nil {
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || appErr.Code != codes.NotFound {
+			log.Ctx(ctx).Warn().Err(err).Str("entity_type", method.EntityType).Str("entity_id", id).Msg("Failed to snapshot audited entity")
+		}
+		return nil
+	}
+
+	return m
+}
+
+// diff returns the fields that differ between before and after as a JSON object
+func diff(before, after proto.Message) (json.RawMessage, error) {
+	b, err := toMap(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]change{}
+	for field, value := range b {
+		if !reflect.DeepEqual(value, a[field]) {
+			changes[field] = change{Before: value, After: a[field]}
+		}
+	}
+	for field, value := range a {
+		if _, ok := b[field]; !ok {
+			changes[field] = change{After: value}
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+func toMap(m proto.Message) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return fields, nil
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
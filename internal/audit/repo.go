@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionBorrow  = "borrow"
+	ActionReturn  = "return"
+)
+
+type Event struct {
+	ID         string
+	OccurredAt time.Time
+	// ActorID is empty for calls between services
+	ActorID    string
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityID   string
+	Method     string
+	RequestID  string
+	// Changes is a JSON object of the changed fields, each as {"before": ..., "after": ...}
+	Changes json.RawMessage
+}
+
+type ListEventsParams struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	Start      time.Time
+	End        time.Time
+	Offset     int
+	Limit      int
+}
+
+// Repository stores audit events. The audit_events table only accepts inserts, see the migration.
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) RecordEvent(ctx context.Context, event *Event) error {
+	event.ID = uuid.New().String()
+	event.OccurredAt = time.Now()
+
+	query := `
+		INSERT INTO audit_events (id, occurred_at, actor_id, actor_role, action, entity_type, entity_id, method, request_id, changes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, event.ID, event.OccurredAt, nullString(event.ActorID), event.ActorRole, event.Action,
+		event.EntityType, event.EntityID, event.Method, event.RequestID, []byte(event.Changes))
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListEvents(ctx context.Context, params ListEventsParams) ([]*Event, int, error) {
+	whereClause := "WHERE 1=1"
+	args := []interface{}{}
+	argCount := 1
+
+	if params.EntityType != "" {
+		whereClause += fmt.Sprintf(" AND entity_type = $%d", argCount)
+		args = append(args, params.EntityType)
+		argCount++
+	}
+
+	if params.EntityID != "" {
+		whereClause += fmt.Sprintf(" AND entity_id = $%d", argCount)
+		args = append(args, params.EntityID)
+		argCount++
+	}
+
+	if params.ActorID != "" {
+		whereClause += fmt.Sprintf(" AND actor_id = $%d", argCount)
+		args = append(args, params.ActorID)
+		argCount++
+	}
+
+	if !params.Start.IsZero() {
+		whereClause += fmt.Sprintf(" AND occurred_at >= $%d", argCount)
+		args = append(args, params.Start)
+		argCount++
+	}
+
+	if !params.End.IsZero() {
+		whereClause += fmt.Sprintf(" AND occurred_at < $%d", argCount)
+		args = append(args, params.End)
+		argCount++
+	}
+
+	var total int
+	err := r.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM audit_events %s", whereClause), args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, occurred_at, COALESCE(actor_id::text, ''), actor_role, action, entity_type, entity_id, method, request_id, changes
+		FROM audit_events
+		%s
+		ORDER BY occurred_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argCount, argCount+1)
+	args = append(args, params.Limit, params.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*Event
+	for rows.Next() {
+		var event Event
+		var changes []byte
+		err := rows.Scan(&event.ID, &event.OccurredAt, &event.ActorID, &event.ActorRole, &event.Action,
+			&event.EntityType, &event.EntityID, &event.Method, &event.RequestID, &changes)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit event row: %w", err)
+		}
+		event.Changes = changes
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error after scanning audit events: %w", err)
+	}
+
+	return events, total, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
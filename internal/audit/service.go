@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/purnasatria/library-management/api/gen/audit"
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
+)
+
+const defaultPageSize = 10
+
+type Service struct {
+	pb.UnimplementedAuditServiceServer
+	repo *Repository
+}
+
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	params := ListEventsParams{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityId,
+		ActorID:    req.ActorId,
+		Offset:     (page - 1) * limit,
+		Limit:      limit,
+	}
+	if req.StartTime != nil {
+		params.Start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		params.End = req.EndTime.AsTime()
+	}
+
+	events, total, err := s.repo.ListEvents(ctx, params)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list audit events")
+	}
+
+	pbEvents := make([]*pb.AuditEvent, len(events))
+	for i, event := range events {
+		pbEvent, err := eventToProto(event)
+		if err != nil {
+			return nil, apperror.ToStatus(err, "failed to convert audit event to proto")
+		}
+		pbEvents[i] = pbEvent
+	}
+
+	return &pb.ListAuditEventsResponse{
+		Events: pbEvents,
+		Total:  int32(total),
+	}, nil
+}
+
+func eventToProto(event *Event) (*pb.AuditEvent, error) {
+	changes := &structpb.Struct{}
+	if err := protojson.Unmarshal(event.Changes, changes); err != nil {
+		return nil, err
+	}
+
+	return &pb.AuditEvent{
+		Id:         event.ID,
+		OccurredAt: timestamppb.New(event.OccurredAt),
+		ActorId:    event.ActorID,
+		ActorRole:  event.ActorRole,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityId:   event.EntityID,
+		Method:     event.Method,
+		RequestId:  event.RequestID,
+		Changes:    changes,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
+
+	pb "github.com/purnasatria/library-management/api/gen/auth"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// apiKeyPrefix tells API keys apart from other secrets, e.g. for secret scanners
+	apiKeyPrefix = "lmk_"
+	// apiKeyDisplayLength is how much of a key is kept to show which key it is
+	apiKeyDisplayLength = 12
+	// apiKeyUsePrecision is how precisely the last use of a key is recorded
+	apiKeyUsePrecision = time.Minute
+)
+
+func (s *Service) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	claims, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Role != RoleAdmin {
+		return nil, ErrPermissionDenied
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.AsTime()
+		if !t.After(time.Now()) {
+			return nil, ErrAPIKeyExpiryInPast
+		}
+		expiresAt = &t
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to generate API key")
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := &APIKey{
+		ID:        uuid.New().String(),
+		Name:      req.Name,
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   hashToken(key),
+		Scopes:    req.Scopes,
+		CreatedBy: claims.Subject,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repo.CreateAPIKey(apiKey); err != nil {
+		return nil, apperror.ToStatus(err, "failed to create API key")
+	}
+
+	log.Ctx(ctx).Info().Str("api_key_id", apiKey.ID).Strs("scopes", apiKey.Scopes).Str("user_id", claims.Subject).Msg("API key created")
+
+	return &pb.CreateAPIKeyResponse{
+		ApiKey: apiKeyToProto(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	keys, total, err := s.repo.ListAPIKeys(req.IncludeRevoked, (page-1)*limit, limit)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list API keys")
+	}
+
+	pbKeys := make([]*pb.APIKey, len(keys))
+	for i, key := range keys {
+		pbKeys[i] = apiKeyToProto(key)
+	}
+
+	return &pb.ListAPIKeysResponse{
+		ApiKeys: pbKeys,
+		Total:   int32(total),
+	}, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	found, err := s.repo.RevokeAPIKey(req.Id)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to revoke API key")
+	}
+	if !found {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	return &pb.RevokeAPIKeyResponse{Success: true}, nil
+}
+
+// VerifyAPIKey is called by the other services, which forward the address of their client.
+// Invalid keys count as failed logins of that address, so keys cannot be guessed.
+func (s *Service) VerifyAPIKey(ctx context.Context, req *pb.VerifyAPIKeyRequest) (*pb.VerifyAPIKeyResponse, error) {
+	ip := grpcprotocol.ClientIP(ctx)
+	if err := s.checkLoginBlocked(LoginSubjectIP, ip); err != nil {
+		return nil, err
+	}
+
+	key, err := s.activeAPIKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		s.recordLoginFailure(ctx, LoginSubjectIP, ip, s.cfg.IPLockout)
+		failedLogins.WithLabelValues(loginFailureAPIKey).Inc()
+		return &pb.VerifyAPIKeyResponse{Valid: false}, nil
+	}
+
+	// The key works even if its use could not be recorded
+	if err := s.repo.TouchAPIKey(key.ID, apiKeyUsePrecision); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("api_key_id", key.ID).Msg("Failed to record API key use")
+	}
+
+	return &pb.VerifyAPIKeyResponse{
+		Valid:  true,
+		KeyId:  key.ID,
+		Scopes: key.Scopes,
+	}, nil
+}
+
+// activeAPIKey returns the API key, nil if there is none or it is revoked or expired
+func (s *Service) activeAPIKey(key string) (*APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, nil
+	}
+
+	apiKey, err := s.repo.GetAPIKeyByHash(hashToken(key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperror.ToStatus(err, "failed to get API key")
+	}
+
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(time.Now())) {
+		return nil, nil
+	}
+	return apiKey, nil
+}
+
+func apiKeyToProto(key *APIKey) *pb.APIKey {
+	pbKey := &pb.APIKey{
+		Id:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedBy: key.CreatedBy,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+	if key.ExpiresAt != nil {
+		pbKey.ExpiresAt = timestamppb.New(*key.ExpiresAt)
+	}
+	if key.LastUsedAt != nil {
+		pbKey.LastUsedAt = timestamppb.New(*key.LastUsedAt)
+	}
+	if key.RevokedAt != nil {
+		pbKey.RevokedAt = timestamppb.New(*key.RevokedAt)
+	}
+	return pbKey
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+)
+
+// dummyHash is compared with the password of unknown users, so logging in as one takes as
+// long as logging in with a wrong password
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// LockoutPolicy limits the failed logins of an account or IP address
+type LockoutPolicy struct {
+	// After FreeAttempts failures every further failure refuses logins for BaseDelay, doubled
+	// with each failure
+	FreeAttempts int
+	BaseDelay    time.Duration
+	// MaxAttempts failures refuse logins for LockoutDuration
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	// ResetAfter without a failure, the failures are forgotten
+	ResetAfter time.Duration
+}
+
+// blockFor returns how long logins are refused after the given number of failures
+func (p LockoutPolicy) blockFor(failures int) time.Duration {
+	if p.MaxAttempts > 0 && failures >= p.MaxAttempts {
+		return p.LockoutDuration
+	}
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+
+	doublings := failures - p.FreeAttempts - 1
+	if doublings > 30 {
+		return p.LockoutDuration
+	}
+	delay := p.BaseDelay << doublings
+	if delay > p.LockoutDuration {
+		return p.LockoutDuration
+	}
+	return delay
+}
+
+// checkLoginBlocked refuses logins of a subject while it is blocked, telling the client when
+// to try again
+func (s *Service) checkLoginBlocked(subjectType, subject string) error {
+	if subject == "" {
+		return nil
+	}
+
+	blockedUntil, err := s.repo.GetLoginBlockedUntil(subjectType, subject)
+	if err != nil {
+		return apperror.ToStatus(err, "failed to check login lockout")
+	}
+	if blockedUntil != nil {
+		return apperror.WithRetryDelay(ErrTooManyLoginAttempts, time.Until(*blockedUntil).Round(time.Second))
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login of a subject and blocks it as the policy says. The
+// login has failed already, so errors are only logged.
+func (s *Service) recordLoginFailure(ctx context.Context, subjectType, subject string, policy LockoutPolicy) {
+	if subject == "" {
+		return
+	}
+
+	failures, err := s.repo.RecordLoginFailure(subjectType, subject, policy.ResetAfter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("subject_type", subjectType).Msg("Failed to record login failure")
+		return
+	}
+
+	blockFor := policy.blockFor(failures)
+	if blockFor <= 0 {
+		return
+	}
+
+	if policy.MaxAttempts > 0 && failures >= policy.MaxAttempts {
+		log.Ctx(ctx).Warn().Str("subject_type", subjectType).Str("subject", subject).Int("failures", failures).Msg("Login locked")
+	}
+
+	if err := s.repo.BlockLogin(subjectType, subject, time.Now().Add(blockFor)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("subject_type", subjectType).Msg("Failed to block login")
+	}
+}
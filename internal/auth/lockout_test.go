@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutPolicyBlockFor(t *testing.T) {
+	policy := LockoutPolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxAttempts:     10,
+		LockoutDuration: 15 * time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		policy   LockoutPolicy
+		failures int
+		want     time.Duration
+	}{
+		{name: "no failures", policy: policy, failures: 0, want: 0},
+		{name: "below the threshold", policy: policy, failures: 2, want: 0},
+		{name: "at the threshold", policy: policy, failures: 3, want: 0},
+		{name: "first failure past the threshold", policy: policy, failures: 4, want: time.Second},
+		{name: "doubled", policy: policy, failures: 5, want: 2 * time.Second},
+		{name: "one before the maximum", policy: policy, failures: 9, want: 32 * time.Second},
+		{name: "at the maximum", policy: policy, failures: 10, want: 15 * time.Minute},
+		{name: "past the maximum", policy: policy, failures: 11, want: 15 * time.Minute},
+		{
+			name:     "delay capped at the lockout duration",
+			policy:   LockoutPolicy{FreeAttempts: 0, BaseDelay: time.Minute, LockoutDuration: 5 * time.Minute},
+			failures: 4,
+			want:     5 * time.Minute,
+		},
+		{
+			name:     "no maximum, many failures",
+			policy:   LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Second, LockoutDuration: time.Hour},
+			failures: 100,
+			want:     time.Hour,
+		},
+		{
+			name:     "no threshold",
+			policy:   LockoutPolicy{BaseDelay: time.Second, MaxAttempts: 5, LockoutDuration: time.Hour},
+			failures: 1,
+			want:     time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.blockFor(tt.failures); got != tt.want {
+				t.Errorf("blockFor(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reasons a login fails, the reason label of failedLogins
+const (
+	loginFailurePassword  = "password"
+	loginFailureTwoFactor = "two_factor"
+	loginFailureAPIKey    = "api_key"
+)
+
+var failedLogins = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "library_failed_logins_total",
+	Help: "Number of logins that failed with a wrong password, second factor or API key, by reason.",
+}, []string{"reason"})
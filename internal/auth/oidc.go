@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+
+	pb "github.com/purnasatria/library-management/api/gen/auth"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConfig configures single sign-on with an OpenID Connect provider
+type OIDCConfig struct {
+	// IssuerURL is where the provider's discovery document is found, empty disables single
+	// sign-on
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is registered with the provider, the page there passes the code and state it
+	// receives to CompleteOIDCLogin
+	RedirectURL string
+	// Scopes are requested besides openid, email and profile give new users their details
+	Scopes []string
+	// LoginTTL is how long a login can take at the provider
+	LoginTTL time.Duration
+}
+
+// oidcProvider discovers the provider on first use, so the auth service starts while the
+// provider is down
+type oidcProvider struct {
+	cfg OIDCConfig
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func newOIDCProvider(cfg OIDCConfig) *oidcProvider {
+	if cfg.IssuerURL == "" {
+		return nil
+	}
+	return &oidcProvider{cfg: cfg}
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider == nil {
+		provider, err := oidc.NewProvider(ctx, p.cfg.IssuerURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		p.provider = provider
+	}
+
+	return p.provider, &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     p.provider.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, p.cfg.Scopes...),
+	}, nil
+}
+
+// oidcClaims are the claims of an ID token new and linked users are set up with
+type oidcClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+func (s *Service) StartOIDCLogin(ctx context.Context, req *pb.StartOIDCLoginRequest) (*pb.StartOIDCLoginResponse, error) {
+	if s.oidc == nil {
+		return nil, ErrSSONotConfigured
+	}
+
+	// A signed in user links the identity to their account
+	var linkUserID *string
+	if _, ok := bearerToken(ctx); ok {
+		claims, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		linkUserID = &claims.Subject
+	}
+
+	_, oauthConfig, err := s.oidc.discover(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("issuer", s.cfg.OIDC.IssuerURL).Msg("Failed to discover identity provider")
+		return nil, ErrIdentityProviderUnavailable
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to generate state")
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to generate nonce")
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	err = s.repo.CreateOIDCLoginState(&OIDCLoginState{
+		StateHash:    hashToken(state),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    time.Now().Add(s.cfg.OIDC.LoginTTL),
+	})
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to store login state")
+	}
+
+	return &pb.StartOIDCLoginResponse{
+		AuthorizationUrl: oauthConfig.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)),
+	}, nil
+}
+
+func (s *Service) CompleteOIDCLogin(ctx context.Context, req *pb.CompleteOIDCLoginRequest) (*pb.LoginResponse, error) {
+	if s.oidc == nil {
+		return nil, ErrSSONotConfigured
+	}
+
+	state, err := s.repo.ConsumeOIDCLoginState(hashToken(req.State))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, apperror.ToStatus(err, "failed to get login state")
+	}
+
+	provider, oauthConfig, err := s.oidc.discover(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("issuer", s.cfg.OIDC.IssuerURL).Msg("Failed to discover identity provider")
+		return nil, ErrIdentityProviderUnavailable
+	}
+
+	token, err := oauthConfig.Exchange(ctx, req.Code, oauth2.VerifierOption(state.CodeVerifier))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to exchange authorization code")
+		return nil, ErrSSOFailed
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		log.Ctx(ctx).Warn().Msg("Token response without ID token")
+		return nil, ErrSSOFailed
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.cfg.OIDC.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Invalid ID token")
+		return nil, ErrSSOFailed
+	}
+	if idToken.Nonce != state.Nonce {
+		log.Ctx(ctx).Warn().Str("subject", idToken.Subject).Msg("ID token nonce mismatch")
+		return nil, ErrSSOFailed
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Invalid ID token claims")
+		return nil, ErrSSOFailed
+	}
+
+	user, err := s.oidcUser(ctx, state, idToken.Issuer, idToken.Subject, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.SuspendedAt != nil {
+		return nil, ErrUserSuspended
+	}
+
+	// The provider replaces the password, not the second factor
+	if user.TOTPEnabledAt != nil {
+		return s.loginChallenge(user)
+	}
+
+	return s.loginResponse(user)
+}
+
+// oidcUser returns the user an identity is linked to. An identity that is not linked yet is
+// linked to the signed in user who started the login, or else to the user with the same
+// verified email; otherwise a new user is created for it.
+func (s *Service) oidcUser(ctx context.Context, state *OIDCLoginState, issuer, subject string, claims oidcClaims) (*User, error) {
+	user, err := s.repo.GetUserByIdentity(issuer, subject)
+	if err == nil {
+		if state.LinkUserID != nil && *state.LinkUserID != user.ID {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ToStatus(err, "failed to get user")
+	}
+
+	identity := &UserIdentity{
+		ID:      uuid.New().String(),
+		Issuer:  issuer,
+		Subject: subject,
+		Email:   claims.Email,
+	}
+
+	if state.LinkUserID != nil {
+		user, err := s.getUser(*state.LinkUserID)
+		if err != nil {
+			return nil, err
+		}
+		return s.linkIdentity(ctx, user, identity)
+	}
+
+	if claims.Email == "" {
+		return nil, ErrSSOEmailMissing
+	}
+
+	user, err = s.repo.GetUserByEmail(claims.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ToStatus(err, "failed to get user")
+	}
+	if user != nil {
+		// Both sides must have verified the email, or whoever registered it first could take
+		// over the account
+		if !claims.EmailVerified || user.EmailVerifiedAt == nil {
+			return nil, ErrEmailAlreadyRegistered
+		}
+		return s.linkIdentity(ctx, user, identity)
+	}
+
+	return s.createOIDCUser(ctx, identity, claims)
+}
+
+func (s *Service) linkIdentity(ctx context.Context, user *User, identity *UserIdentity) (*User, error) {
+	identity.UserID = user.ID
+	if err := s.repo.CreateIdentity(identity); err != nil {
+		return nil, apperror.ToStatus(err, "failed to link identity")
+	}
+
+	log.Ctx(ctx).Info().Str("user_id", user.ID).Str("issuer", identity.Issuer).Msg("Identity linked")
+	return user, nil
+}
+
+// createOIDCUser creates a member for an identity. The user has no password until they reset
+// it, so they log in with the provider.
+func (s *Service) createOIDCUser(ctx context.Context, identity *UserIdentity, claims oidcClaims) (*User, error) {
+	base := claims.PreferredUsername
+	if base == "" || strings.Contains(base, "@") {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = usernameInvalidChars.ReplaceAllString(base, "")
+	if len(base) > MaxUsernameLength {
+		base = base[:MaxUsernameLength]
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	username, err := s.repo.FreeUsername(base)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to find a free username")
+	}
+
+	user := &User{
+		ID:       uuid.New().String(),
+		Username: username,
+		Email:    claims.Email,
+		Role:     RoleMember,
+		FullName: claims.Name,
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	identity.UserID = user.ID
+	if err := s.repo.CreateUserWithIdentity(user, identity); err != nil {
+		return nil, apperror.ToStatus(err, "failed to create user")
+	}
+
+	log.Ctx(ctx).Info().Str("user_id", user.ID).Str("issuer", identity.Issuer).Msg("User created from identity")
+	return s.getUser(user.ID)
+}
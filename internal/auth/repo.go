@@ -2,13 +2,144 @@ package auth
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	RoleMember = "member"
+	RoleAdmin  = "admin"
+)
+
+// Purposes of the single-use tokens sent by email
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+	// TokenPurposeLoginChallenge is a login waiting for the second factor
+	TokenPurposeLoginChallenge = "login_challenge"
+)
+
+// MaxUsernameLength is the length of users.username
+const MaxUsernameLength = 50
+
+// Subjects failed logins are tracked for
+const (
+	LoginSubjectUser = "user"
+	// LoginSubjectUnknown is a username that does not exist, tracked like an account so
+	// lockouts do not reveal which usernames exist
+	LoginSubjectUnknown = "login"
+	LoginSubjectIP      = "ip"
 )
 
 type User struct {
-	ID       string
-	Username string
-	Email    string
-	Password string
+	ID                string
+	Username          string
+	Email             string
+	Password          string
+	Role              string
+	EmailVerifiedAt   *time.Time
+	LibraryCardNumber string
+	FullName          string
+	Phone             string
+	Address           string
+	CreatedAt         time.Time
+	SuspendedAt       *time.Time
+	// TOTPSecret is set on enrollment, TOTPEnabledAt once the user confirmed it with a code
+	TOTPSecret    string
+	TOTPEnabledAt *time.Time
+}
+
+const userColumns = "id, username, email, password, role, email_verified_at, library_card_number, full_name, phone, address, created_at, suspended_at, " +
+	"COALESCE(totp_secret, ''), totp_enabled_at"
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*User, error) {
+	user := &User{}
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.EmailVerifiedAt,
+		&user.LibraryCardNumber, &user.FullName, &user.Phone, &user.Address, &user.CreatedAt, &user.SuspendedAt,
+		&user.TOTPSecret, &user.TOTPEnabledAt)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RefreshToken is an issued refresh token. Only its hash is stored.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	// UsedAt is set once the token has been exchanged for a new one
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// UserIdentity links a user to their identity at an OpenID Connect provider
+type UserIdentity struct {
+	ID      string
+	UserID  string
+	Issuer  string
+	Subject string
+	Email   string
+}
+
+// OIDCLoginState is a login in progress at the provider, stored by the hash of its state
+type OIDCLoginState struct {
+	StateHash    string
+	CodeVerifier string
+	Nonce        string
+	// LinkUserID is set when a signed in user links an identity
+	LinkUserID *string
+	ExpiresAt  time.Time
+}
+
+// APIKey is a key of a machine client. Only its hash is stored.
+type APIKey struct {
+	ID      string
+	Name    string
+	Prefix  string
+	KeyHash string
+	Scopes  []string
+	// CreatedBy is empty once the admin who created the key is deleted
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+const apiKeyColumns = "id, name, prefix, key_hash, scopes, COALESCE(created_by::text, ''), created_at, expires_at, last_used_at, revoked_at"
+
+func scanAPIKey(row rowScanner) (*APIKey, error) {
+	key := &APIKey{}
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, pq.Array(&key.Scopes), &key.CreatedBy, &key.CreatedAt,
+		&key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+type LoginFailure struct {
+	SubjectType   string
+	Subject       string
+	Failures      int
+	LastFailureAt time.Time
+	BlockedUntil  *time.Time
 }
 
 type Repository struct {
@@ -20,17 +151,710 @@ func NewRepository(db *sql.DB) *Repository {
 }
 
 func (r *Repository) CreateUser(user *User) error {
-	_, err := r.db.Exec("INSERT INTO users (id, username, email, password) VALUES ($1, $2, $3, $4)",
-		user.ID, user.Username, user.Email, user.Password)
+	_, err := r.db.Exec("INSERT INTO users (id, username, email, password, role) VALUES ($1, $2, $3, $4, $5)",
+		user.ID, user.Username, user.Email, user.Password, user.Role)
 	return err
 }
 
+// GetUserByUsername ignores case, usernames are unique regardless of it
 func (r *Repository) GetUserByUsername(username string) (*User, error) {
-	user := &User{}
-	err := r.db.QueryRow("SELECT id, username, email, password FROM users WHERE username = $1", username).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Password)
+	return r.getUser("lower(username) = lower($1)", username)
+}
+
+func (r *Repository) GetUserByID(id string) (*User, error) {
+	return r.getUser("id = $1", id)
+}
+
+// GetUserByEmail ignores case, emails are unique regardless of it
+func (r *Repository) GetUserByEmail(email string) (*User, error) {
+	return r.getUser("lower(email) = lower($1)", email)
+}
+
+// GetUserByLogin looks a user up by email if login is one. New usernames cannot contain an @,
+// but users registered before that rule may have one, so a login with an @ that is no email
+// is looked up as a username.
+func (r *Repository) GetUserByLogin(login string) (*User, error) {
+	if strings.Contains(login, "@") {
+		user, err := r.GetUserByEmail(login)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return user, err
+		}
+	}
+	return r.GetUserByUsername(login)
+}
+
+// GetUserByIdentity returns the user an identity of a provider is linked to
+func (r *Repository) GetUserByIdentity(issuer, subject string) (*User, error) {
+	return r.getUser("id = (SELECT user_id FROM user_identities WHERE issuer = $1 AND subject = $2)", issuer, subject)
+}
+
+func (r *Repository) getUser(condition string, args ...interface{}) (*User, error) {
+	return scanUser(r.db.QueryRow("SELECT "+userColumns+" FROM users WHERE "+condition, args...))
+}
+
+// CreateUserWithIdentity creates a user on their first login with a provider
+func (r *Repository) CreateUserWithIdentity(user *User, identity *UserIdentity) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO users (id, username, email, password, role, full_name, email_verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, user.ID, user.Username, user.Email, user.Password, user.Role, user.FullName, user.EmailVerifiedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := createIdentity(tx, identity); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Repository) CreateIdentity(identity *UserIdentity) error {
+	return createIdentity(r.db, identity)
+}
+
+func createIdentity(db execer, identity *UserIdentity) error {
+	_, err := db.Exec("INSERT INTO user_identities (id, user_id, issuer, subject, email) VALUES ($1, $2, $3, $4, $5)",
+		identity.ID, identity.UserID, identity.Issuer, identity.Subject, identity.Email)
+	return err
+}
+
+// CreateOIDCLoginState stores a login in progress and forgets the expired ones
+func (r *Repository) CreateOIDCLoginState(state *OIDCLoginState) error {
+	if _, err := r.db.Exec("DELETE FROM oidc_login_states WHERE expires_at < NOW()"); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec("INSERT INTO oidc_login_states (state_hash, code_verifier, nonce, link_user_id, expires_at) VALUES ($1, $2, $3, $4, $5)",
+		state.StateHash, state.CodeVerifier, state.Nonce, state.LinkUserID, state.ExpiresAt)
+	return err
+}
+
+// ConsumeOIDCLoginState returns a login in progress and forgets it, so a state works once. It
+// returns sql.ErrNoRows if the state does not exist or has expired.
+func (r *Repository) ConsumeOIDCLoginState(stateHash string) (*OIDCLoginState, error) {
+	state := &OIDCLoginState{}
+	err := r.db.QueryRow(`
+		DELETE FROM oidc_login_states WHERE state_hash = $1 AND expires_at > NOW()
+		RETURNING state_hash, code_verifier, nonce, link_user_id, expires_at
+	`, stateHash).Scan(&state.StateHash, &state.CodeVerifier, &state.Nonce, &state.LinkUserID, &state.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
-	return user, nil
+	return state, nil
+}
+
+// ListUsers returns users whose username, email, name or library card number contains query,
+// in any case, newest first
+func (r *Repository) ListUsers(query string, suspendedOnly bool, offset, limit int) ([]*User, int, error) {
+	whereClause := `WHERE (username ILIKE $1 OR email ILIKE $1 OR full_name ILIKE $1 OR library_card_number ILIKE $1)`
+	if suspendedOnly {
+		whereClause += " AND suspended_at IS NOT NULL"
+	}
+	pattern := "%" + query + "%"
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM users "+whereClause, pattern).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query("SELECT "+userColumns+" FROM users "+whereClause+" ORDER BY created_at DESC, id LIMIT $2 OFFSET $3",
+		pattern, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	return users, total, rows.Err()
+}
+
+// UpdateProfile stores the contact details of a user, email_verified_at included so a changed
+// email can be marked as unverified
+func (r *Repository) UpdateProfile(user *User) error {
+	_, err := r.db.Exec(`
+		UPDATE users SET full_name = $2, phone = $3, address = $4, email = $5, email_verified_at = $6, updated_at = NOW()
+		WHERE id = $1
+	`, user.ID, user.FullName, user.Phone, user.Address, user.Email, user.EmailVerifiedAt)
+	return err
+}
+
+// SetUserSuspended suspends or reinstates a user, it returns false if the user does not exist
+func (r *Repository) SetUserSuspended(userID string, suspended bool) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE users SET suspended_at = CASE WHEN $2 THEN COALESCE(suspended_at, NOW()) END, updated_at = NOW()
+		WHERE id = $1
+	`, userID, suspended)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// DeleteUser deletes a user along with their tokens and sessions
+func (r *Repository) DeleteUser(userID string) error {
+	_, err := r.db.Exec("DELETE FROM users WHERE id = $1", userID)
+	return err
+}
+
+// FreeUsername returns username if no user has it regardless of case, otherwise the first of
+// username_2, username_3, ... that is free, shortened to fit
+func (r *Repository) FreeUsername(username string) (string, error) {
+	for n := 1; ; n++ {
+		candidate := username
+		if n > 1 {
+			suffix := fmt.Sprintf("_%d", n)
+			if len(candidate)+len(suffix) > MaxUsernameLength {
+				candidate = candidate[:MaxUsernameLength-len(suffix)]
+			}
+			candidate += suffix
+		}
+
+		_, err := r.GetUserByUsername(candidate)
+		if errors.Is(err, sql.ErrNoRows) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (r *Repository) UpdatePassword(userID, password string) error {
+	_, err := r.db.Exec("UPDATE users SET password = $2, updated_at = NOW() WHERE id = $1", userID, password)
+	return err
+}
+
+func (r *Repository) MarkEmailVerified(userID string) error {
+	_, err := r.db.Exec("UPDATE users SET email_verified_at = NOW(), updated_at = NOW() WHERE id = $1 AND email_verified_at IS NULL", userID)
+	return err
+}
+
+func (r *Repository) CreateRefreshToken(token *RefreshToken) error {
+	_, err := r.db.Exec("INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at) VALUES ($1, $2, $3, $4, $5)",
+		token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt)
+	return err
+}
+
+func (r *Repository) GetRefreshTokenByHash(tokenHash string) (*RefreshToken, error) {
+	token := &RefreshToken{}
+	err := r.db.QueryRow("SELECT id, user_id, family_id, token_hash, expires_at, used_at, revoked_at FROM refresh_tokens WHERE token_hash = $1", tokenHash).
+		Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// MarkRefreshTokenUsed marks a token as exchanged, it returns false if the token was already
+// used or revoked
+func (r *Repository) MarkRefreshTokenUsed(id string) (bool, error) {
+	result, err := r.db.Exec("UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL", id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *Repository) RevokeRefreshTokenFamily(familyID string) error {
+	_, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL", familyID)
+	return err
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of a user and returns the number of
+// sessions that were still active
+func (r *Repository) RevokeUserRefreshTokens(userID string) (int, error) {
+	var sessions int
+	err := r.db.QueryRow(`
+		WITH revoked AS (
+			UPDATE refresh_tokens SET revoked_at = NOW()
+			WHERE user_id = $1 AND revoked_at IS NULL
+			RETURNING used_at, expires_at
+		)
+		SELECT COUNT(*) FROM revoked WHERE used_at IS NULL AND expires_at > NOW()
+	`, userID).Scan(&sessions)
+	return sessions, err
+}
+
+// RevokeAccessToken denylists a single access token until it expires
+func (r *Repository) RevokeAccessToken(jti, userID string, expiresAt time.Time) error {
+	_, err := r.db.Exec("INSERT INTO revoked_access_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING",
+		jti, userID, expiresAt)
+	return err
+}
+
+// RevokeUserAccessTokens revokes every access token issued to a user before the given time, it
+// returns false if the user does not exist. The time comes from the clock tokens are issued
+// with, not the database's.
+func (r *Repository) RevokeUserAccessTokens(userID string, before time.Time) (bool, error) {
+	result, err := r.db.Exec("UPDATE users SET tokens_revoked_at = $2 WHERE id = $1", userID, before)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// IsAccessTokenRevoked reports whether an access token was revoked, either on its own or with
+// all tokens of its user. Tokens of users that no longer exist or are suspended are revoked too.
+func (r *Repository) IsAccessTokenRevoked(jti, userID string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM revoked_access_tokens WHERE jti = $1)
+			OR NOT EXISTS (SELECT 1 FROM users WHERE id = $2 AND suspended_at IS NULL AND (tokens_revoked_at IS NULL OR tokens_revoked_at < $3))
+	`, jti, userID, issuedAt).Scan(&revoked)
+	return revoked, err
+}
+
+// ListRevokedAccessTokens returns the IDs of the access tokens revoked on their own that have
+// not expired yet
+func (r *Repository) ListRevokedAccessTokens() ([]string, error) {
+	rows, err := r.db.Query("SELECT jti FROM revoked_access_tokens WHERE expires_at > NOW()")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jtis []string
+	for rows.Next() {
+		var jti string
+		if err := rows.Scan(&jti); err != nil {
+			return nil, err
+		}
+		jtis = append(jtis, jti)
+	}
+	return jtis, rows.Err()
+}
+
+// ListUserRevocations returns, by user ID, when all access tokens of a user were revoked, for
+// revocations after the given time. Suspended users are listed with the zero time, all of
+// their tokens are revoked.
+func (r *Repository) ListUserRevocations(since time.Time) (map[string]time.Time, error) {
+	rows, err := r.db.Query(`
+		SELECT id, CASE WHEN suspended_at IS NULL THEN tokens_revoked_at END FROM users
+		WHERE tokens_revoked_at > $1 OR suspended_at IS NOT NULL
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revocations := make(map[string]time.Time)
+	for rows.Next() {
+		var userID string
+		var revokedAt sql.NullTime
+		if err := rows.Scan(&userID, &revokedAt); err != nil {
+			return nil, err
+		}
+		revocations[userID] = revokedAt.Time
+	}
+	return revocations, rows.Err()
+}
+
+// CreateUserToken stores a single-use token, invalidating the unused tokens of the user with
+// the same purpose so only the latest link works
+func (r *Repository) CreateUserToken(userID, purpose, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE user_tokens SET used_at = NOW() WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL", userID, purpose)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at) VALUES ($1, $2, $3, $4, $5)",
+		uuid.New().String(), userID, purpose, tokenHash, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ConsumeUserToken uses up a token and returns its user. It returns sql.ErrNoRows if the token
+// does not exist, has expired or has already been used.
+func (r *Repository) ConsumeUserToken(purpose, tokenHash string) (string, error) {
+	var userID string
+	err := r.db.QueryRow(`
+		UPDATE user_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`, tokenHash, purpose).Scan(&userID)
+	return userID, err
+}
+
+// GetUserToken returns the user of a token without using it up. It returns sql.ErrNoRows if the
+// token does not exist, has expired or has already been used.
+func (r *Repository) GetUserToken(purpose, tokenHash string) (string, error) {
+	var userID string
+	err := r.db.QueryRow("SELECT user_id FROM user_tokens WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()",
+		tokenHash, purpose).Scan(&userID)
+	return userID, err
+}
+
+// SetTOTPSecret stores the secret of a new enrollment, it returns false if two-factor
+// authentication is already enabled for the user
+func (r *Repository) SetTOTPSecret(userID, secret string) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE users SET totp_secret = $2, totp_last_counter = NULL, updated_at = NOW()
+		WHERE id = $1 AND totp_enabled_at IS NULL
+	`, userID, secret)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// EnableTOTP enables the enrolled secret of a user along with their recovery codes, it returns
+// false if there is nothing to enable
+func (r *Repository) EnableTOTP(userID string, codeHashes []string) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE users SET totp_enabled_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL
+	`, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected != 1 {
+		return false, nil
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// DisableTOTP removes the secret and recovery codes of a user, it returns false if the user
+// does not exist
+func (r *Repository) DisableTOTP(userID string) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_counter = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected != 1 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec("DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// UseTOTPCounter records the time step of an accepted code, it returns false if a code of the
+// same or a later step was accepted before
+func (r *Repository) UseTOTPCounter(userID string, counter int64) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE users SET totp_last_counter = $2
+		WHERE id = $1 AND (totp_last_counter IS NULL OR totp_last_counter < $2)
+	`, userID, counter)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// ReplaceRecoveryCodes deletes the recovery codes of a user and stores new ones
+func (r *Repository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func replaceRecoveryCodes(tx *sql.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.Exec("DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		_, err := tx.Exec("INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)",
+			uuid.New().String(), userID, codeHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode uses up a recovery code, it returns false if the user has no such unused code
+func (r *Repository) UseRecoveryCode(userID, codeHash string) (bool, error) {
+	result, err := r.db.Exec("UPDATE recovery_codes SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL",
+		userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetLoginBlockedUntil returns until when logins of a subject are refused, nil if they are not
+func (r *Repository) GetLoginBlockedUntil(subjectType, subject string) (*time.Time, error) {
+	var blockedUntil *time.Time
+	err := r.db.QueryRow("SELECT blocked_until FROM login_failures WHERE subject_type = $1 AND subject = $2 AND blocked_until > NOW()",
+		subjectType, subject).Scan(&blockedUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return blockedUntil, err
+}
+
+// RecordLoginFailure counts a failed login and returns the number of failures of the subject.
+// The count starts over when the last failure is older than resetAfter.
+func (r *Repository) RecordLoginFailure(subjectType, subject string, resetAfter time.Duration) (int, error) {
+	var failures int
+	err := r.db.QueryRow(`
+		INSERT INTO login_failures (subject_type, subject, failures, last_failure_at)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (subject_type, subject) DO UPDATE SET
+			failures = CASE
+				WHEN login_failures.last_failure_at < NOW() - make_interval(secs => $3) THEN 1
+				ELSE login_failures.failures + 1
+			END,
+			last_failure_at = NOW()
+		RETURNING failures
+	`, subjectType, subject, resetAfter.Seconds()).Scan(&failures)
+	return failures, err
+}
+
+func (r *Repository) BlockLogin(subjectType, subject string, until time.Time) error {
+	_, err := r.db.Exec("UPDATE login_failures SET blocked_until = $3 WHERE subject_type = $1 AND subject = $2",
+		subjectType, subject, until)
+	return err
+}
+
+func (r *Repository) ClearLoginFailures(subjectType, subject string) error {
+	_, err := r.db.Exec("DELETE FROM login_failures WHERE subject_type = $1 AND subject = $2", subjectType, subject)
+	return err
+}
+
+func (r *Repository) ListLoginFailures(blockedOnly bool, offset, limit int) ([]*LoginFailure, int, error) {
+	whereClause := ""
+	if blockedOnly {
+		whereClause = "WHERE blocked_until > NOW()"
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM login_failures " + whereClause).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT subject_type, subject, failures, last_failure_at, blocked_until
+		FROM login_failures
+		`+whereClause+`
+		ORDER BY last_failure_at DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var failures []*LoginFailure
+	for rows.Next() {
+		failure := &LoginFailure{}
+		if err := rows.Scan(&failure.SubjectType, &failure.Subject, &failure.Failures, &failure.LastFailureAt, &failure.BlockedUntil); err != nil {
+			return nil, 0, err
+		}
+		failures = append(failures, failure)
+	}
+
+	return failures, total, rows.Err()
+}
+
+func (r *Repository) CreateAPIKey(key *APIKey) error {
+	return r.db.QueryRow(`
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`, key.ID, key.Name, key.Prefix, key.KeyHash, pq.Array(key.Scopes), key.CreatedBy, key.ExpiresAt).Scan(&key.CreatedAt)
+}
+
+func (r *Repository) GetAPIKeyByHash(keyHash string) (*APIKey, error) {
+	return scanAPIKey(r.db.QueryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", keyHash))
+}
+
+func (r *Repository) ListAPIKeys(includeRevoked bool, offset, limit int) ([]*APIKey, int, error) {
+	whereClause := ""
+	if !includeRevoked {
+		whereClause = "WHERE revoked_at IS NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM api_keys " + whereClause).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query("SELECT "+apiKeyColumns+" FROM api_keys "+whereClause+" ORDER BY created_at DESC, id LIMIT $1 OFFSET $2",
+		limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, total, rows.Err()
+}
+
+// RevokeAPIKey revokes a key, it returns false if the key does not exist
+func (r *Repository) RevokeAPIKey(id string) (bool, error) {
+	result, err := r.db.Exec("UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1", id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// TouchAPIKey records that a key was used. To save writes the time is only updated once it is
+// older than precision.
+func (r *Repository) TouchAPIKey(id string, precision time.Duration) error {
+	_, err := r.db.Exec(`
+		UPDATE api_keys SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $2))
+	`, id, precision.Seconds())
+	return err
+}
+
+// DuplicateUser is one of the users whose username or email only differs in case from another's
+type DuplicateUser struct {
+	ID        string
+	Username  string
+	Email     string
+	CreatedAt time.Time
+	// Field is "username" or "email", Key its lowercase value shared by the duplicates
+	Field string
+	Key   string
+}
+
+// FindDuplicateUsers returns the users that share a username or email regardless of case,
+// grouped by field and key and the oldest user first
+func (r *Repository) FindDuplicateUsers() ([]*DuplicateUser, error) {
+	rows, err := r.db.Query(`
+		SELECT id, username, email, created_at, field, key FROM (
+			SELECT id, username, email, created_at, 'username' AS field, lower(username) AS key,
+				COUNT(*) OVER (PARTITION BY lower(username)) AS duplicates
+			FROM users
+			UNION ALL
+			SELECT id, username, email, created_at, 'email' AS field, lower(email) AS key,
+				COUNT(*) OVER (PARTITION BY lower(email)) AS duplicates
+			FROM users
+		) AS u
+		WHERE duplicates > 1
+		ORDER BY field DESC, key, created_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*DuplicateUser
+	for rows.Next() {
+		user := &DuplicateUser{}
+		if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.Field, &user.Key); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (r *Repository) RenameUser(id, username string) error {
+	_, err := r.db.Exec("UPDATE users SET username = $2, updated_at = NOW() WHERE id = $1", id, username)
+	return err
 }
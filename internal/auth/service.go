@@ -2,34 +2,100 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/purnasatria/library-management/pkg/apperror"
 	"github.com/purnasatria/library-management/pkg/jwt"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/purnasatria/library-management/pkg/mailer"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
 
 	pb "github.com/purnasatria/library-management/api/gen/auth"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var (
+	ErrInvalidCredentials = apperror.Unauthenticated("invalid credentials")
+	ErrInvalidToken       = apperror.Unauthenticated("invalid token")
+	ErrMissingToken       = apperror.Unauthenticated("missing bearer token")
+	ErrPermissionDenied   = apperror.PermissionDenied("permission denied")
+	ErrUserNotFound       = apperror.NotFound("user not found")
+	ErrUserSuspended      = apperror.PermissionDenied("user suspended")
+
+	ErrEmailAlreadyVerified = apperror.FailedPrecondition("email already verified")
+	ErrTooManyLoginAttempts = apperror.ResourceExhausted("too many failed login attempts")
+	ErrCannotSuspendSelf    = apperror.FailedPrecondition("cannot suspend yourself")
+
+	ErrInvalidTwoFactorCode    = apperror.Unauthenticated("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled = apperror.FailedPrecondition("two-factor authentication already enabled")
+	ErrTwoFactorNotEnrolled    = apperror.FailedPrecondition("two-factor authentication not enrolled")
+	ErrTwoFactorNotEnabled     = apperror.FailedPrecondition("two-factor authentication not enabled")
+	ErrTwoFactorRequired       = apperror.FailedPrecondition("two-factor authentication required for role")
+
+	ErrSSONotConfigured            = apperror.FailedPrecondition("single sign-on not configured")
+	ErrSSOFailed                   = apperror.Unauthenticated("single sign-on failed")
+	ErrSSOEmailMissing             = apperror.FailedPrecondition("identity provider returned no email")
+	ErrIdentityAlreadyLinked       = apperror.AlreadyExists("identity already linked to another user")
+	ErrEmailAlreadyRegistered      = apperror.AlreadyExists("email already registered, log in to link the identity")
+	ErrIdentityProviderUnavailable = apperror.Unavailable("identity provider unavailable")
+
+	ErrAPIKeyNotFound     = apperror.NotFound("API key not found")
+	ErrAPIKeyExpiryInPast = apperror.InvalidArgument("API key expiry must be in the future")
+)
+
+const defaultPageSize = 10
+
+// profileFields are the fields UpdateProfile can change
+var profileFields = []string{"full_name", "phone", "address", "email"}
+
+type Config struct {
+	// BaseURL is where the links in emails point to, e.g. the web app
+	BaseURL              string
+	PasswordResetTTL     time.Duration
+	EmailVerificationTTL time.Duration
+	// AccountLockout limits failed logins per account, IPLockout per client IP address
+	AccountLockout LockoutPolicy
+	IPLockout      LockoutPolicy
+	// TwoFactorIssuer names the service in authenticator apps
+	TwoFactorIssuer string
+	// TwoFactorRequiredRoles have to use two-factor authentication, until they enable it their
+	// tokens only carry the member role
+	TwoFactorRequiredRoles []string
+	// LoginChallengeTTL is how long a login waits for the second factor
+	LoginChallengeTTL time.Duration
+	OIDC              OIDCConfig
+}
+
 type Service struct {
 	pb.UnimplementedAuthServiceServer
-	repo *Repository
-	jwt  *jwt.JWT
+	repo   *Repository
+	jwt    *jwt.JWT
+	mailer mailer.Mailer
+	oidc   *oidcProvider
+	cfg    Config
 }
 
-func NewService(repo *Repository, jwt *jwt.JWT) *Service {
-	return &Service{repo: repo, jwt: jwt}
+func NewService(repo *Repository, jwt *jwt.JWT, mailer mailer.Mailer, cfg Config) *Service {
+	return &Service{repo: repo, jwt: jwt, mailer: mailer, oidc: newOIDCProvider(cfg.OIDC), cfg: cfg}
 }
 
 func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	// Generate a salt and hash the password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "server error: %v", err)
+		return nil, apperror.ToStatus(err, "failed to hash password")
 	}
 
 	user := &User{
@@ -37,76 +103,748 @@ func (s *Service) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Re
 		Username: req.Username,
 		Email:    req.Email,
 		Password: string(hashedPassword), // Store the salted and hashed password
+		Role:     RoleMember,
 	}
 
 	if err := s.repo.CreateUser(user); err != nil {
-		return nil, status.Errorf(codes.Internal, "server error: %v", err)
+		return nil, apperror.ToStatus(err, "failed to create user")
+	}
+
+	// The user can ask for another email, so registering does not fail without one
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("Failed to send verification email")
 	}
 
 	return &pb.RegisterResponse{UserId: user.ID}, nil
 }
 
 func (s *Service) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	user, err := s.repo.GetUserByUsername(req.Username)
+	ip := grpcprotocol.ClientIP(ctx)
+	if err := s.checkLoginBlocked(LoginSubjectIP, ip); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByLogin(req.Username)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ToStatus(err, "failed to get user")
+	}
+
+	// Unknown users are compared with a dummy hash and locked out like accounts, so neither the
+	// response time nor a lockout tells whether a username exists
+	subjectType, subject, hashedPassword := LoginSubjectUnknown, strings.ToLower(req.Username), dummyHash
+	if user != nil {
+		subjectType, subject, hashedPassword = LoginSubjectUser, user.ID, []byte(user.Password)
+	}
+
+	if err := s.checkLoginBlocked(subjectType, subject); err != nil {
+		return nil, err
+	}
+
+	// Compare the provided password with the stored hashed password
+	err = bcrypt.CompareHashAndPassword(hashedPassword, []byte(req.Password))
+	if err != nil || user == nil {
+		s.recordLoginFailure(ctx, subjectType, subject, s.cfg.AccountLockout)
+		s.recordLoginFailure(ctx, LoginSubjectIP, ip, s.cfg.IPLockout)
+		failedLogins.WithLabelValues(loginFailurePassword).Inc()
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.repo.ClearLoginFailures(LoginSubjectUser, user.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("Failed to clear login failures")
+	}
+
+	// Only tell whoever knows the password that the account is suspended
+	if user.SuspendedAt != nil {
+		return nil, ErrUserSuspended
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return s.loginChallenge(user)
+	}
+
+	return s.loginResponse(user)
+}
+
+func (s *Service) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	stored, err := s.storedRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// A refresh token is exchanged only once, so a used token has been stolen from either the
+	// user or the thief. Revoking the whole session logs out both.
+	rotated, err := s.repo.MarkRefreshTokenUsed(stored.ID)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to rotate refresh token")
+	}
+	if !rotated {
+		log.Ctx(ctx).Warn().Str("user_id", stored.UserID).Str("family_id", stored.FamilyID).Msg("Refresh token reused, revoking session")
+		if err := s.repo.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+			return nil, apperror.ToStatus(err, "failed to revoke session")
+		}
+		return nil, ErrInvalidToken
+	}
+
+	// Read the user again so role changes apply from the next refresh
+	user, err := s.repo.GetUserByID(stored.UserID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("invalid credentials")
+			return nil, ErrInvalidToken
 		}
+		return nil, apperror.ToStatus(err, "failed to get user")
+	}
+	if user.SuspendedAt != nil {
+		return nil, ErrUserSuspended
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(user, stored.FamilyID)
+	if err != nil {
 		return nil, err
 	}
 
-	// Compare the provided password with the stored hashed password
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	return &pb.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (s *Service) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	stored, err := s.storedRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+		return nil, apperror.ToStatus(err, "failed to revoke session")
+	}
+
+	// The access token of the session is revoked as well when the caller sends it
+	if token, ok := bearerToken(ctx); ok {
+		if claims, err := s.jwt.ValidateAccessToken(token); err == nil && claims.Subject == stored.UserID {
+			if err := s.repo.RevokeAccessToken(claims.ID, claims.Subject, claims.ExpiresAt.Time); err != nil {
+				return nil, apperror.ToStatus(err, "failed to revoke access token")
+			}
+		}
+	}
+
+	return &pb.LogoutResponse{Success: true}, nil
+}
+
+func (s *Service) LogoutAllSessions(ctx context.Context, req *pb.LogoutAllSessionsRequest) (*pb.LogoutAllSessionsResponse, error) {
+	claims, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.revokeUserTokens(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LogoutAllSessionsResponse{RevokedSessions: int32(sessions)}, nil
+}
+
+func (s *Service) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	user, err := s.repo.GetUserByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &pb.RequestPasswordResetResponse{Success: true}, nil
+		}
+		return nil, apperror.ToStatus(err, "failed to get user")
+	}
+
+	// Failures past this point are only logged, the response must not tell whether the email
+	// belongs to a user
+	token, err := s.createUserToken(user.ID, TokenPurposePasswordReset, s.cfg.PasswordResetTTL)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("Failed to create password reset token")
+		return &pb.RequestPasswordResetResponse{Success: true}, nil
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %s.\n\n%s/reset-password?token=%s\n\n"+
+			"If you did not ask to reset your password, you can ignore this email.\n",
+			user.Username, s.cfg.PasswordResetTTL, s.cfg.BaseURL, token),
+	})
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		log.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("Failed to send password reset email")
 	}
 
-	accessToken, err := s.jwt.GenerateAccessToken(user.ID)
+	return &pb.RequestPasswordResetResponse{Success: true}, nil
+}
+
+func (s *Service) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	userID, err := s.repo.ConsumeUserToken(TokenPurposePasswordReset, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, apperror.ToStatus(err, "failed to use password reset token")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to hash password")
+	}
+
+	if err := s.repo.UpdatePassword(userID, string(hashedPassword)); err != nil {
+		return nil, apperror.ToStatus(err, "failed to update password")
+	}
+
+	// Whoever knew the old password is logged out, and the owner may log in again
+	if _, err := s.revokeUserTokens(userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.ClearLoginFailures(LoginSubjectUser, userID); err != nil {
+		return nil, apperror.ToStatus(err, "failed to clear login failures")
+	}
+
+	return &pb.ResetPasswordResponse{Success: true}, nil
+}
+
+func (s *Service) SendVerificationEmail(ctx context.Context, req *pb.SendVerificationEmailRequest) (*pb.SendVerificationEmailResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil, ErrEmailAlreadyVerified
+	}
+
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		return nil, apperror.ToStatus(err, "failed to send verification email")
+	}
+
+	return &pb.SendVerificationEmailResponse{Success: true}, nil
+}
+
+func (s *Service) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	userID, err := s.repo.ConsumeUserToken(TokenPurposeEmailVerification, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, apperror.ToStatus(err, "failed to use verification token")
+	}
+
+	if err := s.repo.MarkEmailVerified(userID); err != nil {
+		return nil, apperror.ToStatus(err, "failed to verify email")
+	}
+
+	return &pb.VerifyEmailResponse{Success: true}, nil
+}
+
+func (s *Service) GetMe(ctx context.Context, req *pb.GetMeRequest) (*pb.UserResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Service) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserResponse, error) {
+	fields, err := grpcprotocol.UpdateFields(req.UpdateMask, profileFields...)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.jwt.GenerateRefreshToken(user.ID)
+	user, err := s.currentUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.LoginResponse{
+	if fields["full_name"] {
+		user.FullName = req.FullName
+	}
+	if fields["phone"] {
+		user.Phone = req.Phone
+	}
+	if fields["address"] {
+		user.Address = req.Address
+	}
+
+	// A new email has to be verified again
+	emailChanged := fields["email"] && !strings.EqualFold(req.Email, user.Email)
+	if fields["email"] {
+		user.Email = req.Email
+	}
+	if emailChanged {
+		user.EmailVerifiedAt = nil
+	}
+
+	if err := s.repo.UpdateProfile(user); err != nil {
+		return nil, apperror.ToStatus(err, "failed to update profile")
+	}
+
+	if emailChanged {
+		if err := s.sendVerificationEmail(ctx, user); err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("Failed to send verification email")
+		}
+	}
+
+	return &pb.UserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Service) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPassword(ctx, user, req.CurrentPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to hash password")
+	}
+
+	if err := s.repo.UpdatePassword(user.ID, string(hashedPassword)); err != nil {
+		return nil, apperror.ToStatus(err, "failed to update password")
+	}
+
+	// Every other session may have been started with the old password
+	if _, err := s.revokeUserTokens(user.ID); err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(user, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ChangePasswordResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
 
-func (s *Service) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	userID, err := s.jwt.ValidateRefreshToken(req.RefreshToken)
+func (s *Service) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	user, err := s.currentUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	accessToken, err := s.jwt.GenerateAccessToken(userID)
+	if err := s.checkPassword(ctx, user, req.Password); err != nil {
+		return nil, err
+	}
+
+	// Tokens are deleted with the user, and access tokens of users that no longer exist are
+	// revoked
+	if err := s.repo.DeleteUser(user.ID); err != nil {
+		return nil, apperror.ToStatus(err, "failed to delete user")
+	}
+
+	return &pb.DeleteAccountResponse{Success: true}, nil
+}
+
+func (s *Service) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	users, total, err := s.repo.ListUsers(req.Query, req.SuspendedOnly, (page-1)*limit, limit)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list users")
+	}
+
+	pbUsers := make([]*pb.User, len(users))
+	for i, user := range users {
+		pbUsers[i] = userToProto(user)
+	}
+
+	return &pb.ListUsersResponse{
+		Users: pbUsers,
+		Total: int32(total),
+	}, nil
+}
+
+func (s *Service) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(req.UserId)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.jwt.GenerateRefreshToken(userID)
+	return &pb.UserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Service) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.UserResponse, error) {
+	claims, err := s.authenticate(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if claims.Role != RoleAdmin {
+		return nil, ErrPermissionDenied
+	}
 
-	return &pb.RefreshTokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	// An admin suspending themselves could lock out the last admin
+	if req.Suspended && req.UserId == claims.Subject {
+		return nil, ErrCannotSuspendSelf
+	}
+
+	found, err := s.repo.SetUserSuspended(req.UserId, req.Suspended)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to suspend user")
+	}
+	if !found {
+		return nil, ErrUserNotFound
+	}
+
+	// Access tokens stop working while the user is suspended, sessions end for good
+	if req.Suspended {
+		if _, err := s.repo.RevokeUserRefreshTokens(req.UserId); err != nil {
+			return nil, apperror.ToStatus(err, "failed to revoke sessions")
+		}
+	}
+
+	user, err := s.getUser(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UserResponse{User: userToProto(user)}, nil
+}
+
+func (s *Service) RevokeUserTokens(ctx context.Context, req *pb.RevokeUserTokensRequest) (*pb.RevokeUserTokensResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.revokeUserTokens(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeUserTokensResponse{RevokedSessions: int32(sessions)}, nil
+}
+
+func (s *Service) ListLoginLockouts(ctx context.Context, req *pb.ListLoginLockoutsRequest) (*pb.ListLoginLockoutsResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+
+	failures, total, err := s.repo.ListLoginFailures(req.BlockedOnly, (page-1)*limit, limit)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list login lockouts")
+	}
+
+	lockouts := make([]*pb.LoginLockout, len(failures))
+	for i, failure := range failures {
+		lockouts[i] = &pb.LoginLockout{
+			SubjectType:   failure.SubjectType,
+			Subject:       failure.Subject,
+			Failures:      int32(failure.Failures),
+			LastFailureAt: timestamppb.New(failure.LastFailureAt),
+		}
+		if failure.BlockedUntil != nil && failure.BlockedUntil.After(time.Now()) {
+			lockouts[i].BlockedUntil = timestamppb.New(*failure.BlockedUntil)
+		}
+	}
+
+	return &pb.ListLoginLockoutsResponse{
+		Lockouts: lockouts,
+		Total:    int32(total),
 	}, nil
 }
 
+func (s *Service) UnlockLogin(ctx context.Context, req *pb.UnlockLoginRequest) (*pb.UnlockLoginResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	subjectType, subject := LoginSubjectUser, req.GetUserId()
+	if req.GetIpAddress() != "" {
+		subjectType, subject = LoginSubjectIP, req.GetIpAddress()
+	}
+
+	if err := s.repo.ClearLoginFailures(subjectType, subject); err != nil {
+		return nil, apperror.ToStatus(err, "failed to unlock login")
+	}
+
+	return &pb.UnlockLoginResponse{Success: true}, nil
+}
+
 func (s *Service) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
-	userID, err := s.jwt.ValidateAccessToken(req.Token)
+	claims, err := s.validateAccessToken(req.Token)
 	if err != nil {
-		return &pb.VerifyTokenResponse{Valid: false}, nil
+		if errors.Is(err, ErrInvalidToken) {
+			return &pb.VerifyTokenResponse{Valid: false}, nil
+		}
+		return nil, err
 	}
 
 	return &pb.VerifyTokenResponse{
 		Valid:  true,
-		UserId: userID,
+		UserId: claims.Subject,
+		Role:   claims.Role,
 	}, nil
 }
+
+// ListRevocations returns the revocations that still affect unexpired access tokens. Tokens of
+// users that no longer exist are not listed.
+func (s *Service) ListRevocations(ctx context.Context, req *pb.ListRevocationsRequest) (*pb.ListRevocationsResponse, error) {
+	tokenIDs, err := s.repo.ListRevokedAccessTokens()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list revoked access tokens")
+	}
+
+	// Older revocations only cover tokens that have expired since
+	users, err := s.repo.ListUserRevocations(time.Now().Add(-s.jwt.AccessTokenExpirationTime()))
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list revoked users")
+	}
+
+	resp := &pb.ListRevocationsResponse{TokenIds: tokenIDs}
+	for userID, revokedBefore := range users {
+		revocation := &pb.UserRevocation{UserId: userID}
+		if !revokedBefore.IsZero() {
+			revocation.RevokedBefore = timestamppb.New(revokedBefore)
+		}
+		resp.Users = append(resp.Users, revocation)
+	}
+
+	return resp, nil
+}
+
+// issueTokens creates an access token and a refresh token in the given session
+func (s *Service) issueTokens(user *User, familyID string) (string, string, error) {
+	accessToken, err := s.jwt.GenerateAccessToken(user.ID, s.tokenRole(user))
+	if err != nil {
+		return "", "", apperror.ToStatus(err, "failed to generate access token")
+	}
+
+	refreshToken, expiresAt, err := s.jwt.GenerateRefreshToken(user.ID)
+	if err != nil {
+		return "", "", apperror.ToStatus(err, "failed to generate refresh token")
+	}
+
+	err = s.repo.CreateRefreshToken(&RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", "", apperror.ToStatus(err, "failed to store refresh token")
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// storedRefreshToken validates a refresh token and returns it as issued
+func (s *Service) storedRefreshToken(refreshToken string) (*RefreshToken, error) {
+	if _, err := s.jwt.ValidateRefreshToken(refreshToken); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	stored, err := s.repo.GetRefreshTokenByHash(hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, apperror.ToStatus(err, "failed to get refresh token")
+	}
+
+	return stored, nil
+}
+
+func (s *Service) sendVerificationEmail(ctx context.Context, user *User) error {
+	token, err := s.createUserToken(user.ID, TokenPurposeEmailVerification, s.cfg.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address with the link below. It expires in %s.\n\n%s/verify-email?token=%s\n",
+			user.Username, s.cfg.EmailVerificationTTL, s.cfg.BaseURL, token),
+	})
+}
+
+// createUserToken creates a random single-use token, only its hash is stored
+func (s *Service) createUserToken(userID, purpose string, ttl time.Duration) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", apperror.ToStatus(err, "failed to generate token")
+	}
+
+	if err := s.repo.CreateUserToken(userID, purpose, hashToken(token), time.Now().Add(ttl)); err != nil {
+		return "", apperror.ToStatus(err, "failed to store token")
+	}
+
+	return token, nil
+}
+
+// currentUser returns the user of the access token the caller sent
+func (s *Service) currentUser(ctx context.Context) (*User, error) {
+	claims, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.getUser(claims.Subject)
+}
+
+func (s *Service) getUser(userID string) (*User, error) {
+	user, err := s.repo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, apperror.ToStatus(err, "failed to get user")
+	}
+	return user, nil
+}
+
+// checkPassword confirms the password of a signed in user. Wrong passwords count towards the
+// account lockout like failed logins, so a stolen access token does not allow guessing it.
+func (s *Service) checkPassword(ctx context.Context, user *User, password string) error {
+	if err := s.checkLoginBlocked(LoginSubjectUser, user.ID); err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		s.recordLoginFailure(ctx, LoginSubjectUser, user.ID, s.cfg.AccountLockout)
+		return ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+// revokeUserTokens revokes every access and refresh token of a user and returns the number of
+// sessions that were still active
+func (s *Service) revokeUserTokens(userID string) (int, error) {
+	found, err := s.repo.RevokeUserAccessTokens(userID, time.Now())
+	if err != nil {
+		return 0, apperror.ToStatus(err, "failed to revoke access tokens")
+	}
+	if !found {
+		return 0, ErrUserNotFound
+	}
+
+	sessions, err := s.repo.RevokeUserRefreshTokens(userID)
+	if err != nil {
+		return 0, apperror.ToStatus(err, "failed to revoke sessions")
+	}
+
+	return sessions, nil
+}
+
+// validateAccessToken checks the signature and expiry of an access token and that it has not
+// been revoked
+func (s *Service) validateAccessToken(token string) (*jwt.Claims, error) {
+	claims, err := s.jwt.ValidateAccessToken(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := s.repo.IsAccessTokenRevoked(claims.ID, claims.Subject, claims.IssuedAtTime())
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to check token revocation")
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// authenticate returns the claims of the access token the caller sent as a bearer token
+func (s *Service) authenticate(ctx context.Context) (*jwt.Claims, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return nil, ErrMissingToken
+	}
+	return s.validateAccessToken(token)
+}
+
+// requireAdmin rejects callers whose access token is not an admin's
+func (s *Service) requireAdmin(ctx context.Context) error {
+	claims, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if claims.Role != RoleAdmin {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// bearerToken returns the token of the authorization metadata, which the HTTP gateway sets
+// from the Authorization header
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+func userToProto(user *User) *pb.User {
+	pbUser := &pb.User{
+		Id:                user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		EmailVerified:     user.EmailVerifiedAt != nil,
+		Role:              user.Role,
+		LibraryCardNumber: user.LibraryCardNumber,
+		FullName:          user.FullName,
+		Phone:             user.Phone,
+		Address:           user.Address,
+		CreatedAt:         timestamppb.New(user.CreatedAt),
+		TwoFactorEnabled:  user.TOTPEnabledAt != nil,
+	}
+	if user.SuspendedAt != nil {
+		pbUser.SuspendedAt = timestamppb.New(*user.SuspendedAt)
+	}
+	return pbUser
+}
+
+// randomToken returns 256 random bits, URL-safe base64 encoded
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the SHA-256 hash of a token, tokens are only stored hashed
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
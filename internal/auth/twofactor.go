@@ -0,0 +1,350 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
+	"github.com/purnasatria/library-management/pkg/totp"
+
+	pb "github.com/purnasatria/library-management/api/gen/auth"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// totpSkew accepts the codes of the time steps before and after the current one
+	totpSkew          = 1
+	recoveryCodeCount = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func (s *Service) VerifyTwoFactor(ctx context.Context, req *pb.VerifyTwoFactorRequest) (*pb.LoginResponse, error) {
+	ip := grpcprotocol.ClientIP(ctx)
+	if err := s.checkLoginBlocked(LoginSubjectIP, ip); err != nil {
+		return nil, err
+	}
+
+	// The challenge is only used up by the right code, a wrong one counts as a failed login
+	challengeHash := hashToken(req.ChallengeToken)
+	userID, err := s.repo.GetUserToken(TokenPurposeLoginChallenge, challengeHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, apperror.ToStatus(err, "failed to get login challenge")
+	}
+
+	user, err := s.getUser(userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if err := s.checkSecondFactor(ctx, user, req.Code, true); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			s.recordLoginFailure(ctx, LoginSubjectIP, ip, s.cfg.IPLockout)
+			failedLogins.WithLabelValues(loginFailureTwoFactor).Inc()
+		}
+		return nil, err
+	}
+
+	if _, err := s.repo.ConsumeUserToken(TokenPurposeLoginChallenge, challengeHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidToken
+		}
+		return nil, apperror.ToStatus(err, "failed to use login challenge")
+	}
+
+	if err := s.repo.ClearLoginFailures(LoginSubjectUser, user.ID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("Failed to clear login failures")
+	}
+
+	if user.SuspendedAt != nil {
+		return nil, ErrUserSuspended
+	}
+
+	return s.loginResponse(user)
+}
+
+func (s *Service) EnrollTwoFactor(ctx context.Context, req *pb.EnrollTwoFactorRequest) (*pb.EnrollTwoFactorResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.checkPassword(ctx, user, req.Password); err != nil {
+		return nil, err
+	}
+
+	// Enrolling again replaces a secret that was never confirmed
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to generate secret")
+	}
+
+	enrolled, err := s.repo.SetTOTPSecret(user.ID, secret)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to store secret")
+	}
+	if !enrolled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	return &pb.EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningUri: totp.URI(s.cfg.TwoFactorIssuer, user.Username, secret),
+	}, nil
+}
+
+func (s *Service) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.ConfirmTwoFactorResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	if err := s.checkSecondFactor(ctx, user, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to generate recovery codes")
+	}
+
+	enabled, err := s.repo.EnableTOTP(user.ID, codeHashes)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to enable two-factor authentication")
+	}
+	if !enabled {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+
+	// Sessions started with the password alone end, the new one carries the full role
+	if _, err := s.revokeUserTokens(user.ID); err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(user, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ConfirmTwoFactorResponse{
+		RecoveryCodes: codes,
+		AccessToken:   accessToken,
+		RefreshToken:  refreshToken,
+	}, nil
+}
+
+func (s *Service) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if s.twoFactorRequired(user.Role) {
+		return nil, ErrTwoFactorRequired
+	}
+
+	if err := s.checkPassword(ctx, user, req.Password); err != nil {
+		return nil, err
+	}
+	if err := s.checkSecondFactor(ctx, user, req.Code, true); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.DisableTOTP(user.ID); err != nil {
+		return nil, apperror.ToStatus(err, "failed to disable two-factor authentication")
+	}
+
+	return &pb.DisableTwoFactorResponse{Success: true}, nil
+}
+
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RegenerateRecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	if err := s.checkSecondFactor(ctx, user, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to generate recovery codes")
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(user.ID, codeHashes); err != nil {
+		return nil, apperror.ToStatus(err, "failed to store recovery codes")
+	}
+
+	return &pb.RegenerateRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *Service) ResetTwoFactor(ctx context.Context, req *pb.ResetTwoFactorRequest) (*pb.ResetTwoFactorResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	found, err := s.repo.DisableTOTP(req.UserId)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to reset two-factor authentication")
+	}
+	if !found {
+		return nil, ErrUserNotFound
+	}
+
+	// The user logs in again, with the member role until they enroll if their role requires it
+	if _, err := s.revokeUserTokens(req.UserId); err != nil {
+		return nil, err
+	}
+
+	return &pb.ResetTwoFactorResponse{Success: true}, nil
+}
+
+// loginResponse starts a new session for a user who passed every factor
+func (s *Service) loginResponse(user *User) (*pb.LoginResponse, error) {
+	// Every login starts a new session, i.e. a new family of refresh tokens
+	accessToken, refreshToken, err := s.issueTokens(user, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		AccessToken:            accessToken,
+		RefreshToken:           refreshToken,
+		TwoFactorSetupRequired: user.TOTPEnabledAt == nil && s.twoFactorRequired(user.Role),
+	}, nil
+}
+
+// loginChallenge asks a user who passed the password for the second factor
+func (s *Service) loginChallenge(user *User) (*pb.LoginResponse, error) {
+	challenge, err := s.createUserToken(user.ID, TokenPurposeLoginChallenge, s.cfg.LoginChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge,
+	}, nil
+}
+
+// checkSecondFactor accepts a current code of the user's authenticator app, each only once, or
+// if allowRecovery is set one of their unused recovery codes. Wrong codes count towards the
+// account lockout like failed logins.
+func (s *Service) checkSecondFactor(ctx context.Context, user *User, code string, allowRecovery bool) error {
+	if err := s.checkLoginBlocked(LoginSubjectUser, user.ID); err != nil {
+		return err
+	}
+
+	accepted, err := s.acceptSecondFactor(ctx, user, strings.TrimSpace(code), allowRecovery)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		s.recordLoginFailure(ctx, LoginSubjectUser, user.ID, s.cfg.AccountLockout)
+		return ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (s *Service) acceptSecondFactor(ctx context.Context, user *User, code string, allowRecovery bool) (bool, error) {
+	if counter, ok := totp.Validate(user.TOTPSecret, code, time.Now(), totpSkew); ok {
+		fresh, err := s.repo.UseTOTPCounter(user.ID, counter)
+		if err != nil {
+			return false, apperror.ToStatus(err, "failed to use code")
+		}
+		return fresh, nil
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+
+	used, err := s.repo.UseRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, apperror.ToStatus(err, "failed to use recovery code")
+	}
+	if used {
+		log.Ctx(ctx).Info().Str("user_id", user.ID).Msg("Recovery code used")
+	}
+	return used, nil
+}
+
+// twoFactorRequired reports whether the policy requires two-factor authentication for a role
+func (s *Service) twoFactorRequired(role string) bool {
+	for _, required := range s.cfg.TwoFactorRequiredRoles {
+		if role == required {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenRole is the role the access tokens of a user carry. A role that requires two-factor
+// authentication only applies once the user has enabled it.
+func (s *Service) tokenRole(user *User) string {
+	if user.TOTPEnabledAt == nil && s.twoFactorRequired(user.Role) {
+		return RoleMember
+	}
+	return user.Role
+}
+
+// generateRecoveryCodes returns new recovery codes, formatted like "abcde-fghij", and their
+// hashes to store
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		// 50 random bits, 10 base32 characters
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b)[:10])
+
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashToken(code)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode accepts recovery codes in any case and with or without the dash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/purnasatria/library-management/pkg/totp"
+)
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"abcde-fghij", "abcdefghij"},
+		{"ABCDE-FGHIJ", "abcdefghij"},
+		{"abcdefghij", "abcdefghij"},
+		{" abcde fghij ", "abcdefghij"},
+		{"AbCdE - fGhIj", "abcdefghij"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeRecoveryCode(tt.code); got != tt.want {
+			t.Errorf("normalizeRecoveryCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("got %d codes and %d hashes, want %d", len(codes), len(hashes), recoveryCodeCount)
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("code %q is not formatted like abcde-fghij", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q generated twice", code)
+		}
+		seen[code] = true
+
+		// The stored hash must match the code however the user types it
+		if hashes[i] != hashToken(normalizeRecoveryCode(strings.ToUpper(code))) {
+			t.Errorf("hash of code %q does not match its normalized form", code)
+		}
+	}
+}
+
+func TestAcceptSecondFactorRejectsReplay(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{repo: NewRepository(openTOTPCounterDB(t))}
+	user := &User{ID: "user-1", TOTPSecret: secret}
+	ctx := context.Background()
+
+	// Codes are taken relative to the current step, which must not end during the test
+	if left := totp.Period - time.Duration(time.Now().UnixNano())%totp.Period; left < 2*time.Second {
+		time.Sleep(left)
+	}
+	codeAt := func(step int64) string {
+		code, err := totp.Code(secret, totp.Counter(time.Now())+step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+	previous, current := codeAt(-1), codeAt(0)
+	if previous == current {
+		t.Skip("codes of consecutive steps collide")
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{"previous step", previous, true},
+		{"current step", current, true},
+		{"current step again", current, false},
+		{"previous step after the current one", previous, false},
+		{"wrong code", "000000", false},
+	}
+
+	for _, tt := range tests {
+		accepted, err := s.acceptSecondFactor(ctx, user, tt.code, false)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if accepted != tt.want {
+			t.Errorf("%s: accepted = %v, want %v", tt.name, accepted, tt.want)
+		}
+	}
+}
+
+// openTOTPCounterDB opens a database that only answers Repository.UseTOTPCounter, keeping the
+// last used counter of each user like the users.totp_last_counter column
+func openTOTPCounterDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(&totpCounterDriver{last: make(map[string]int64)})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type totpCounterDriver struct {
+	mu   sync.Mutex
+	last map[string]int64
+}
+
+func (d *totpCounterDriver) Open(string) (driver.Conn, error) { return totpCounterConn{d}, nil }
+
+func (d *totpCounterDriver) Connect(context.Context) (driver.Conn, error) { return d.Open("") }
+
+func (d *totpCounterDriver) Driver() driver.Driver { return d }
+
+type totpCounterConn struct{ d *totpCounterDriver }
+
+func (c totpCounterConn) Prepare(query string) (driver.Stmt, error) {
+	if !strings.Contains(query, "totp_last_counter < $2") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+	return totpCounterStmt(c), nil
+}
+
+func (c totpCounterConn) Close() error { return nil }
+
+func (c totpCounterConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type totpCounterStmt struct{ d *totpCounterDriver }
+
+func (s totpCounterStmt) Close() error  { return nil }
+func (s totpCounterStmt) NumInput() int { return 2 }
+
+func (s totpCounterStmt) Exec(args []driver.Value) (driver.Result, error) {
+	userID, counter := args[0].(string), args[1].(int64)
+
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if last, ok := s.d.last[userID]; ok && last >= counter {
+		return driver.RowsAffected(0), nil
+	}
+	s.d.last[userID] = counter
+	return driver.RowsAffected(1), nil
+}
+
+func (s totpCounterStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
@@ -0,0 +1,51 @@
+package author
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/purnasatria/library-management/api/gen/author"
+	"github.com/purnasatria/library-management/internal/audit"
+)
+
+const auditEntityType = "author"
+
+// AuditMethods describes how the mutating AuthorService RPCs are audited
+func (s *Service) AuditMethods() map[string]audit.Method {
+	method := func(action string, entityID func(req, resp interface{}) string) audit.Method {
+		return audit.Method{
+			Action:     action,
+			EntityType: auditEntityType,
+			EntityID:   entityID,
+			Snapshot:   s.snapshot,
+		}
+	}
+
+	return map[string]audit.Method{
+		pb.AuthorService_CreateAuthor_FullMethodName:  method(audit.ActionCreate, createdAuthorID),
+		pb.AuthorService_UpdateAuthor_FullMethodName:  method(audit.ActionUpdate, audit.IDFromRequest),
+		pb.AuthorService_DeleteAuthor_FullMethodName:  method(audit.ActionDelete, audit.IDFromRequest),
+		pb.AuthorService_RestoreAuthor_FullMethodName: method(audit.ActionRestore, audit.IDFromRequest),
+	}
+}
+
+func (s *Service) snapshot(ctx context.Context, id string) (proto.Message, error) {
+	author, err := s.repo.GetAuthor(id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.authorToProto(author)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Author, nil
+}
+
+func createdAuthorID(req, resp interface{}) string {
+	if r, ok := resp.(*pb.AuthorResponse); ok {
+		return r.GetAuthor().GetId()
+	}
+	return ""
+}
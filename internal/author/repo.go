@@ -2,9 +2,18 @@ package author
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/purnasatria/library-management/pkg/apperror"
+)
+
+var (
+	ErrAuthorNotFound        = apperror.NotFound("author not found")
+	ErrDeletedAuthorNotFound = apperror.NotFound("deleted author not found")
+	ErrVersionMismatch       = apperror.Aborted("author has been modified by another request")
 )
 
 type Author struct {
@@ -12,8 +21,10 @@ type Author struct {
 	Name      string
 	Biography string
 	BirthDate time.Time
+	Version   int32
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 type Repository struct {
@@ -28,23 +39,28 @@ func (r *Repository) CreateAuthor(author *Author) error {
 	author.ID = uuid.New().String()
 	author.CreatedAt = time.Now()
 	author.UpdatedAt = time.Now()
+	author.Version = 1
 
 	_, err := r.db.Exec(`
-		INSERT INTO authors (id, name, biography, birth_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`, author.ID, author.Name, author.Biography, author.BirthDate, author.CreatedAt, author.UpdatedAt)
+		INSERT INTO authors (id, name, biography, birth_date, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, author.ID, author.Name, author.Biography, author.BirthDate, author.Version, author.CreatedAt, author.UpdatedAt)
 
 	return err
 }
 
-func (r *Repository) GetAuthor(id string) (*Author, error) {
+// GetAuthor returns the author with the given ID, deleted authors are only returned if includeDeleted is set
+func (r *Repository) GetAuthor(id string, includeDeleted bool) (*Author, error) {
 	author := &Author{}
 	err := r.db.QueryRow(`
-		SELECT id, name, biography, birth_date, created_at, updated_at
+		SELECT id, name, biography, birth_date, version, created_at, updated_at, deleted_at
 		FROM authors
-		WHERE id = $1
-	`, id).Scan(&author.ID, &author.Name, &author.Biography, &author.BirthDate, &author.CreatedAt, &author.UpdatedAt)
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
+	`, id, includeDeleted).Scan(&author.ID, &author.Name, &author.Biography, &author.BirthDate, &author.Version, &author.CreatedAt, &author.UpdatedAt, &author.DeletedAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAuthorNotFound
+		}
 		return nil, err
 	}
 
@@ -54,27 +70,73 @@ func (r *Repository) GetAuthor(id string) (*Author, error) {
 func (r *Repository) UpdateAuthor(author *Author) error {
 	author.UpdatedAt = time.Now()
 
-	_, err := r.db.Exec(`
+	// The update only applies if nobody changed the author since it was read (author.Version)
+	err := r.db.QueryRow(`
 		UPDATE authors
-		SET name = $2, biography = $3, birth_date = $4, updated_at = $5
-		WHERE id = $1
-	`, author.ID, author.Name, author.Biography, author.BirthDate, author.UpdatedAt)
+		SET name = $2, biography = $3, birth_date = $4, updated_at = $5, version = version + 1
+		WHERE id = $1 AND version = $6 AND deleted_at IS NULL
+		RETURNING version
+	`, author.ID, author.Name, author.Biography, author.BirthDate, author.UpdatedAt, author.Version).Scan(&author.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrVersionMismatch
+		}
+		return err
+	}
 
-	return err
+	return nil
 }
 
+// DeleteAuthor only marks the author as deleted so it can be restored, PurgeDeletedAuthors removes it
 func (r *Repository) DeleteAuthor(id string) error {
-	_, err := r.db.Exec("DELETE FROM authors WHERE id = $1", id)
-	return err
+	result, err := r.db.Exec(`
+		UPDATE authors
+		SET deleted_at = $2, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
+
+func (r *Repository) RestoreAuthor(id string) error {
+	result, err := r.db.Exec(`
+		UPDATE authors
+		SET deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrDeletedAuthorNotFound
+	}
+	return nil
 }
 
 func (r *Repository) ListAuthors(offset, limit int) ([]*Author, int, error) {
+	return r.listAuthors(false, offset, limit)
+}
+
+func (r *Repository) ListDeletedAuthors(offset, limit int) ([]*Author, int, error) {
+	return r.listAuthors(true, offset, limit)
+}
+
+func (r *Repository) listAuthors(deleted bool, offset, limit int) ([]*Author, int, error) {
 	rows, err := r.db.Query(`
-		SELECT id, name, biography, birth_date, created_at, updated_at
+		SELECT id, name, biography, birth_date, version, created_at, updated_at, deleted_at
 		FROM authors
-		ORDER BY created_at DESC
+		WHERE (deleted_at IS NOT NULL) = $3
+		ORDER BY COALESCE(deleted_at, created_at) DESC
 		LIMIT $1 OFFSET $2
-	`, limit, offset)
+	`, limit, offset, deleted)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -83,7 +145,7 @@ func (r *Repository) ListAuthors(offset, limit int) ([]*Author, int, error) {
 	var authors []*Author
 	for rows.Next() {
 		author := &Author{}
-		err := rows.Scan(&author.ID, &author.Name, &author.Biography, &author.BirthDate, &author.CreatedAt, &author.UpdatedAt)
+		err := rows.Scan(&author.ID, &author.Name, &author.Biography, &author.BirthDate, &author.Version, &author.CreatedAt, &author.UpdatedAt, &author.DeletedAt)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -91,10 +153,55 @@ func (r *Repository) ListAuthors(offset, limit int) ([]*Author, int, error) {
 	}
 
 	var total int
-	err = r.db.QueryRow("SELECT COUNT(*) FROM authors").Scan(&total)
+	err = r.db.QueryRow("SELECT COUNT(*) FROM authors WHERE (deleted_at IS NOT NULL) = $1", deleted).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	return authors, total, nil
 }
+
+// ListPurgeableAuthors returns the IDs of the authors deleted before the given time
+func (r *Repository) ListPurgeableAuthors(before time.Time) ([]string, error) {
+	rows, err := r.db.Query("SELECT id FROM authors WHERE deleted_at < $1", before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// PurgeAuthors permanently removes the given authors that were deleted before the given time
+func (r *Repository) PurgeAuthors(ids []string, before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM authors WHERE id = ANY($1) AND deleted_at < $2", pq.Array(ids), before)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(rows), nil
+}
+
+func checkRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrAuthorNotFound
+	}
+	return nil
+}
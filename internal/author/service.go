@@ -2,23 +2,33 @@ package author
 
 import (
 	"context"
-	"database/sql"
-	"errors"
+	"fmt"
+	"time"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/purnasatria/library-management/api/gen/author"
+	book_pb "github.com/purnasatria/library-management/api/gen/book"
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
 )
 
+const defaultPageSize = 10
+
+// purgeBatchSize is how many deleted authors are checked for books with one call
+const purgeBatchSize = 1000
+
+// updatableFields are the UpdateAuthorRequest fields an update_mask may name
+var updatableFields = []string{"name", "biography", "birth_date"}
+
 type Service struct {
 	pb.UnimplementedAuthorServiceServer
-	repo *Repository
+	repo        *Repository
+	bookService book_pb.BookServiceClient
 }
 
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *Repository, bookService book_pb.BookServiceClient) *Service {
+	return &Service{repo: repo, bookService: bookService}
 }
 
 func (s *Service) CreateAuthor(ctx context.Context, req *pb.CreateAuthorRequest) (*pb.AuthorResponse, error) {
@@ -31,62 +41,181 @@ func (s *Service) CreateAuthor(ctx context.Context, req *pb.CreateAuthorRequest)
 	}
 
 	if err := s.repo.CreateAuthor(author); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create author: %v", err)
+		return nil, apperror.ToStatus(err, "failed to create author")
 	}
 
+	grpcprotocol.SetETag(ctx, author.Version)
 	return s.authorToProto(author)
 }
 
 func (s *Service) GetAuthor(ctx context.Context, req *pb.GetAuthorRequest) (*pb.AuthorResponse, error) {
-	author, err := s.repo.GetAuthor(req.Id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "author not found")
+	if req.IncludeDeleted {
+		if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+			return nil, err
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get author: %v", err)
 	}
 
+	author, err := s.repo.GetAuthor(req.Id, req.IncludeDeleted)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to get author")
+	}
+
+	grpcprotocol.SetETag(ctx, author.Version)
 	return s.authorToProto(author)
 }
 
 func (s *Service) UpdateAuthor(ctx context.Context, req *pb.UpdateAuthorRequest) (*pb.AuthorResponse, error) {
-	author, err := s.repo.GetAuthor(req.Id)
+	version, err := grpcprotocol.ExpectedVersion(ctx, req.Version)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "author not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get author: %v", err)
+		return nil, err
 	}
 
-	author.Name = req.Name
-	author.Biography = req.Biography
-	author.BirthDate = req.BirthDate.AsTime()
+	fields, err := grpcprotocol.UpdateFields(req.UpdateMask, updatableFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.repo.GetAuthor(req.Id, false)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to get author")
+	}
+
+	if author.Version != version {
+		return nil, ErrVersionMismatch
+	}
+
+	if fields["name"] {
+		author.Name = req.Name
+	}
+	if fields["biography"] {
+		author.Biography = req.Biography
+	}
+	if fields["birth_date"] {
+		author.BirthDate = req.BirthDate.AsTime()
+	}
 
 	if err := s.repo.UpdateAuthor(author); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update author: %v", err)
+		return nil, apperror.ToStatus(err, "failed to update author")
 	}
 
+	grpcprotocol.SetETag(ctx, author.Version)
 	return s.authorToProto(author)
 }
 
 func (s *Service) DeleteAuthor(ctx context.Context, req *pb.DeleteAuthorRequest) (*pb.DeleteAuthorResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.DeleteAuthor(req.Id); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "author not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to delete author: %v", err)
+		return nil, apperror.ToStatus(err, "failed to delete author")
 	}
 
 	return &pb.DeleteAuthorResponse{Success: true}, nil
 }
 
+func (s *Service) RestoreAuthor(ctx context.Context, req *pb.RestoreAuthorRequest) (*pb.AuthorResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RestoreAuthor(req.Id); err != nil {
+		return nil, apperror.ToStatus(err, "failed to restore author")
+	}
+
+	author, err := s.repo.GetAuthor(req.Id, false)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to get author")
+	}
+
+	grpcprotocol.SetETag(ctx, author.Version)
+	return s.authorToProto(author)
+}
+
+func (s *Service) ListDeletedAuthors(ctx context.Context, req *pb.ListDeletedAuthorsRequest) (*pb.ListAuthorsResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	offset := (page - 1) * limit
+
+	authors, total, err := s.repo.ListDeletedAuthors(offset, limit)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list deleted authors")
+	}
+
+	pbAuthors := make([]*pb.Author, len(authors))
+	for i, author := range authors {
+		pbAuthor, err := s.authorToProto(author)
+		if err != nil {
+			return nil, err
+		}
+		pbAuthors[i] = pbAuthor.Author
+	}
+
+	return &pb.ListAuthorsResponse{
+		Authors: pbAuthors,
+		Total:   int32(total),
+	}, nil
+}
+
+// PurgeDeletedAuthors permanently removes authors deleted before the given time and returns how
+// many were removed. Authors of books, deleted books included, are kept until those books are
+// purged, as the book service still shows them with their author.
+func (s *Service) PurgeDeletedAuthors(ctx context.Context, before time.Time) (int, error) {
+	ids, err := s.repo.ListPurgeableAuthors(before)
+	if err != nil {
+		return 0, err
+	}
+
+	referenced := make(map[string]bool)
+	for start := 0; start < len(ids); start += purgeBatchSize {
+		resp, err := s.bookService.ListReferencedAuthors(ctx, &book_pb.ListReferencedAuthorsRequest{
+			AuthorIds: ids[start:min(start+purgeBatchSize, len(ids))],
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to check books of deleted authors: %w", err)
+		}
+		for _, id := range resp.AuthorIds {
+			referenced[id] = true
+		}
+	}
+
+	var unreferenced []string
+	for _, id := range ids {
+		if !referenced[id] {
+			unreferenced = append(unreferenced, id)
+		}
+	}
+	if len(unreferenced) == 0 {
+		return 0, nil
+	}
+
+	return s.repo.PurgeAuthors(unreferenced, before)
+}
+
 func (s *Service) ListAuthors(ctx context.Context, req *pb.ListAuthorsRequest) (*pb.ListAuthorsResponse, error) {
-	offset := int(req.Page-1) * int(req.PageSize)
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
 	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	offset := (page - 1) * limit
 
 	authors, total, err := s.repo.ListAuthors(offset, limit)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list authors: %v", err)
+		return nil, apperror.ToStatus(err, "failed to list authors")
 	}
 
 	pbAuthors := make([]*pb.Author, len(authors))
@@ -113,6 +242,15 @@ func (s *Service) authorToProto(author *Author) (*pb.AuthorResponse, error) {
 			BirthDate: timestamppb.New(author.BirthDate),
 			CreatedAt: timestamppb.New(author.CreatedAt),
 			UpdatedAt: timestamppb.New(author.UpdatedAt),
+			Version:   author.Version,
+			DeletedAt: timestampOrNil(author.DeletedAt),
 		},
 	}, nil
 }
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
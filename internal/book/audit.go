@@ -0,0 +1,53 @@
+package book
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/purnasatria/library-management/api/gen/book"
+	"github.com/purnasatria/library-management/internal/audit"
+)
+
+const auditEntityType = "book"
+
+// AuditMethods describes how the mutating BookService RPCs are audited
+func (s *Service) AuditMethods() map[string]audit.Method {
+	method := func(action string, entityID func(req, resp interface{}) string) audit.Method {
+		return audit.Method{
+			Action:     action,
+			EntityType: auditEntityType,
+			EntityID:   entityID,
+			Snapshot:   s.snapshot,
+		}
+	}
+
+	return map[string]audit.Method{
+		pb.BookService_CreateBook_FullMethodName:  method(audit.ActionCreate, createdBookID),
+		pb.BookService_UpdateBook_FullMethodName:  method(audit.ActionUpdate, audit.IDFromRequest),
+		pb.BookService_DeleteBook_FullMethodName:  method(audit.ActionDelete, audit.IDFromRequest),
+		pb.BookService_RestoreBook_FullMethodName: method(audit.ActionRestore, audit.IDFromRequest),
+		pb.BookService_BorrowBook_FullMethodName:  method(audit.ActionBorrow, audit.IDFromRequest),
+		pb.BookService_ReturnBook_FullMethodName:  method(audit.ActionReturn, audit.IDFromRequest),
+	}
+}
+
+func (s *Service) snapshot(ctx context.Context, id string) (proto.Message, error) {
+	book, err := s.repo.GetBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.bookToProto(ctx, book)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Book, nil
+}
+
+func createdBookID(req, resp interface{}) string {
+	if r, ok := resp.(*pb.BookResponse); ok {
+		return r.GetBook().GetId()
+	}
+	return ""
+}
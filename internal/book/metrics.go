@@ -0,0 +1,18 @@
+package book
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	booksBorrowed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "library_books_borrowed_total",
+		Help: "Number of books borrowed.",
+	})
+
+	booksReturned = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "library_books_returned_total",
+		Help: "Number of books returned.",
+	})
+)
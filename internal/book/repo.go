@@ -9,11 +9,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/purnasatria/library-management/pkg/apperror"
 )
 
 var (
-	ErrBookNotFound        = errors.New("book not found")
-	ErrNoAvailableCopies   = errors.New("no available copies")
+	ErrBookNotFound        = apperror.NotFound("book not found")
+	ErrDeletedBookNotFound = apperror.NotFound("deleted book not found")
+	ErrNoAvailableCopies   = apperror.FailedPrecondition("no available copies")
+	ErrCopiesBorrowed      = apperror.FailedPrecondition("total copies cannot be less than the borrowed copies")
+	ErrVersionMismatch     = apperror.Aborted("book has been modified by another request")
 	ErrTransactionRequired = errors.New("transaction is required")
 )
 
@@ -27,8 +31,10 @@ type Book struct {
 	Description     string
 	TotalCopies     int
 	AvailableCopies int
+	Version         int32
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	DeletedAt       *time.Time
 }
 
 type ListBooksParams struct {
@@ -62,13 +68,14 @@ func (r *Repository) CreateBook(ctx context.Context, tx *sql.Tx, book *Book) err
 	book.CreatedAt = time.Now()
 	book.UpdatedAt = time.Now()
 	book.AvailableCopies = book.TotalCopies
+	book.Version = 1
 
 	query := `
-		INSERT INTO books (id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO books (id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := tx.ExecContext(ctx, query, book.ID, book.Title, book.AuthorID, book.ISBN, book.PublicationYear, book.Publisher, book.Description, book.TotalCopies, book.AvailableCopies, book.CreatedAt, book.UpdatedAt)
+	_, err := tx.ExecContext(ctx, query, book.ID, book.Title, book.AuthorID, book.ISBN, book.PublicationYear, book.Publisher, book.Description, book.TotalCopies, book.AvailableCopies, book.Version, book.CreatedAt, book.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create book: %w", err)
 	}
@@ -78,15 +85,15 @@ func (r *Repository) CreateBook(ctx context.Context, tx *sql.Tx, book *Book) err
 
 func (r *Repository) GetBook(ctx context.Context, id string) (*Book, error) {
 	query := `
-		SELECT id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, created_at, updated_at
+		SELECT id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, version, created_at, updated_at, deleted_at
 		FROM books
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var book Book
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&book.ID, &book.Title, &book.AuthorID, &book.ISBN, &book.PublicationYear, &book.Publisher,
-		&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.CreatedAt, &book.UpdatedAt,
+		&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.Version, &book.CreatedAt, &book.UpdatedAt, &book.DeletedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -105,37 +112,198 @@ func (r *Repository) UpdateBook(ctx context.Context, tx *sql.Tx, book *Book) err
 
 	book.UpdatedAt = time.Now()
 
+	// The update only applies if nobody changed the book since it was read (book.Version) and
+	// the new total still covers the borrowed copies. Available copies are shifted by the
+	// change in total copies rather than overwritten so concurrent borrows and returns are not
+	// lost.
 	query := `
 		UPDATE books
 		SET title = $2, author_id = $3, isbn = $4, publication_year = $5, publisher = $6, 
-			description = $7, total_copies = $8, available_copies = $9, updated_at = $10
-		WHERE id = $1
+			description = $7, available_copies = available_copies + ($8 - total_copies), total_copies = $8,
+			updated_at = $9, version = version + 1
+		WHERE id = $1 AND version = $10 AND deleted_at IS NULL AND total_copies - available_copies <= $8
+		RETURNING available_copies, version
 	`
 
-	_, err := tx.ExecContext(ctx, query, book.ID, book.Title, book.AuthorID, book.ISBN, book.PublicationYear, book.Publisher, book.Description, book.TotalCopies, book.AvailableCopies, book.UpdatedAt)
+	err := tx.QueryRowContext(ctx, query, book.ID, book.Title, book.AuthorID, book.ISBN, book.PublicationYear, book.Publisher, book.Description, book.TotalCopies, book.UpdatedAt, book.Version).
+		Scan(&book.AvailableCopies, &book.Version)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return r.updateBookConflict(ctx, tx, book)
+		}
 		return fmt.Errorf("failed to update book: %w", err)
 	}
 
 	return nil
 }
 
+// updateBookConflict tells why UpdateBook changed no row: the book was modified since it was
+// read, or more copies are borrowed than the new total
+func (r *Repository) updateBookConflict(ctx context.Context, tx *sql.Tx, book *Book) error {
+	var version int32
+	var borrowed int
+	err := tx.QueryRowContext(ctx, "SELECT version, total_copies - available_copies FROM books WHERE id = $1 AND deleted_at IS NULL", book.ID).
+		Scan(&version, &borrowed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBookNotFound
+		}
+		return fmt.Errorf("failed to get book: %w", err)
+	}
+
+	if version == book.Version && borrowed > book.TotalCopies {
+		return ErrCopiesBorrowed
+	}
+	return ErrVersionMismatch
+}
+
 func (r *Repository) DeleteBook(ctx context.Context, tx *sql.Tx, id string) error {
 	if tx == nil {
 		return ErrTransactionRequired
 	}
 
-	query := "DELETE FROM books WHERE id = $1"
-	_, err := tx.ExecContext(ctx, query, id)
+	// Books are only marked as deleted so they can be restored, PurgeDeletedBooks removes them
+	query := `
+		UPDATE books
+		SET deleted_at = $2, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, id, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to delete book: %w", err)
 	}
 
+	return checkRowsAffected(result)
+}
+
+func (r *Repository) RestoreBook(ctx context.Context, id string) error {
+	query := `
+		UPDATE books
+		SET deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to restore book: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrDeletedBookNotFound
+	}
+
 	return nil
 }
 
+func (r *Repository) ListDeletedBooks(ctx context.Context, offset, limit int) ([]*Book, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM books WHERE deleted_at IS NOT NULL").Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deleted books: %w", err)
+	}
+
+	query := `
+		SELECT id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, version, created_at, updated_at, deleted_at
+		FROM books
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query deleted books: %w", err)
+	}
+	defer rows.Close()
+
+	var books []*Book
+	for rows.Next() {
+		var book Book
+		err := rows.Scan(
+			&book.ID, &book.Title, &book.AuthorID, &book.ISBN, &book.PublicationYear, &book.Publisher,
+			&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.Version, &book.CreatedAt, &book.UpdatedAt, &book.DeletedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan book row: %w", err)
+		}
+		books = append(books, &book)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error after scanning books: %w", err)
+	}
+
+	return books, total, nil
+}
+
+// ListPurgeableBooks returns the IDs of the books deleted before the given time
+func (r *Repository) ListPurgeableBooks(ctx context.Context, before time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM books WHERE deleted_at < $1", before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query purgeable books: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan purgeable book id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning purgeable books: %w", err)
+	}
+
+	return ids, nil
+}
+
+// PurgeBook permanently removes a book if it was deleted before the given time, it returns
+// false if it was not, e.g. because it has been restored since. Its transactions are kept.
+func (r *Repository) PurgeBook(ctx context.Context, id string, before time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM books WHERE id = $1 AND deleted_at < $2", id, before)
+	if err != nil {
+		return false, fmt.Errorf("failed to purge book: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListReferencedAuthors returns which of the given authors have books, deleted ones included
+func (r *Repository) ListReferencedAuthors(ctx context.Context, authorIDs []string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT DISTINCT author_id FROM books WHERE author_id = ANY($1)", pq.Array(authorIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query referenced authors: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan author id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning authors: %w", err)
+	}
+
+	return ids, nil
+}
+
 func (r *Repository) ListBooks(ctx context.Context, params ListBooksParams) ([]*Book, int, error) {
-	whereClause := "WHERE 1=1"
+	whereClause := "WHERE deleted_at IS NULL"
 	args := []interface{}{}
 	argCount := 1
 
@@ -189,7 +357,7 @@ func (r *Repository) ListBooks(ctx context.Context, params ListBooksParams) ([]*
 
 	// Prepare main query
 	query := fmt.Sprintf(`
-		SELECT id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, created_at, updated_at
+		SELECT id, title, author_id, isbn, publication_year, publisher, description, total_copies, available_copies, version, created_at, updated_at, deleted_at
 		FROM books
 		%s
 	`, whereClause)
@@ -228,7 +396,7 @@ func (r *Repository) ListBooks(ctx context.Context, params ListBooksParams) ([]*
 		var book Book
 		err := rows.Scan(
 			&book.ID, &book.Title, &book.AuthorID, &book.ISBN, &book.PublicationYear, &book.Publisher,
-			&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.CreatedAt, &book.UpdatedAt,
+			&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.Version, &book.CreatedAt, &book.UpdatedAt, &book.DeletedAt,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan book row: %w", err)
@@ -251,9 +419,8 @@ func (r *Repository) BorrowBook(ctx context.Context, tx *sql.Tx, bookID, userID
 	query := `
 		UPDATE books
 		SET available_copies = available_copies - 1
-		WHERE id = $1 AND available_copies > 0
+		WHERE id = $1 AND available_copies > 0 AND deleted_at IS NULL
 		RETURNING available_copies
-    FOR UPDATE SKIP LOCKED
 	`
 
 	var availableCopies int
@@ -284,17 +451,20 @@ func (r *Repository) ReturnBook(ctx context.Context, tx *sql.Tx, bookID, userID,
 		return ErrTransactionRequired
 	}
 
+	// Copies of a deleted book can still be returned
 	query := `
 		UPDATE books
 		SET available_copies = available_copies + 1
 		WHERE id = $1
 		RETURNING available_copies
-    FOR UPDATE SKIP LOCKED
 	`
 
 	var availableCopies int
 	err := tx.QueryRowContext(ctx, query, bookID).Scan(&availableCopies)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBookNotFound
+		}
 		return fmt.Errorf("failed to update book copies: %w", err)
 	}
 
@@ -322,11 +492,12 @@ func (r *Repository) GetBookRecommendations(ctx context.Context, bookID string,
 			LIMIT 50
 		)
 		SELECT b.id, b.title, b.author_id, b.isbn, b.publication_year, b.publisher, 
-			   b.description, b.total_copies, b.available_copies, b.created_at, b.updated_at,
+			   b.description, b.total_copies, b.available_copies, b.version, b.created_at, b.updated_at, b.deleted_at,
 			   COUNT(bt.id) as borrow_count
 		FROM books b
 		LEFT JOIN book_transactions bt ON b.id = bt.book_id AND bt.transaction_type = 'borrow'
 		WHERE b.id != $1 
+		  AND b.deleted_at IS NULL
 		  AND (b.author_id = (SELECT author_id FROM books WHERE id = $1)
 			   OR b.id = ANY($2)
 			   OR b.id IN (SELECT book_id FROM popular_books))
@@ -354,7 +525,7 @@ func (r *Repository) GetBookRecommendations(ctx context.Context, bookID string,
 		var borrowCount int
 		err := rows.Scan(
 			&book.ID, &book.Title, &book.AuthorID, &book.ISBN, &book.PublicationYear, &book.Publisher,
-			&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.CreatedAt, &book.UpdatedAt,
+			&book.Description, &book.TotalCopies, &book.AvailableCopies, &book.Version, &book.CreatedAt, &book.UpdatedAt, &book.DeletedAt,
 			&borrowCount,
 		)
 		if err != nil {
@@ -370,6 +541,17 @@ func (r *Repository) GetBookRecommendations(ctx context.Context, bookID string,
 	return recommendations, nil
 }
 
+func checkRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
 func (r *Repository) WithTransaction(ctx context.Context, fn func(*sql.Tx) error) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -379,7 +561,7 @@ func (r *Repository) WithTransaction(ctx context.Context, fn func(*sql.Tx) error
 	err = fn(tx)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
 		}
 		return err
 	}
@@ -4,15 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	author_pb "github.com/purnasatria/library-management/api/gen/author"
 	pb "github.com/purnasatria/library-management/api/gen/book"
 	category_pb "github.com/purnasatria/library-management/api/gen/category"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	defaultPageSize            = 10
+	defaultRecommendationLimit = 10
+)
+
+// updatableFields are the UpdateBookRequest fields an update_mask may name
+var updatableFields = []string{
+	"title", "author_id", "isbn", "publication_year", "publisher", "description", "total_copies", "category_ids",
+}
+
 type Service struct {
 	pb.UnimplementedBookServiceServer
 	repo            *Repository
@@ -60,93 +71,242 @@ func (s *Service) CreateBook(ctx context.Context, req *pb.CreateBookRequest) (*p
 		return nil
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create book: %v", err)
+		return nil, apperror.ToStatus(err, "failed to create book")
+	}
+
+	resp, err := s.bookToProto(ctx, book)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to convert book to proto")
 	}
 
-	return s.bookToProto(ctx, book)
+	grpcprotocol.SetETag(ctx, book.Version)
+	return resp, nil
 }
 
 func (s *Service) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.BookResponse, error) {
 	book, err := s.repo.GetBook(ctx, req.Id)
 	if err != nil {
-		if err == ErrBookNotFound {
-			return nil, status.Errorf(codes.NotFound, "book not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get book: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get book")
+	}
+
+	resp, err := s.bookToProto(ctx, book)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to convert book to proto")
 	}
 
-	return s.bookToProto(ctx, book)
+	grpcprotocol.SetETag(ctx, book.Version)
+	return resp, nil
 }
 
 func (s *Service) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.BookResponse, error) {
+	version, err := grpcprotocol.ExpectedVersion(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := grpcprotocol.UpdateFields(req.UpdateMask, updatableFields...)
+	if err != nil {
+		return nil, err
+	}
+
 	var book *Book
-	err := s.repo.WithTransaction(ctx, func(tx *sql.Tx) error {
+	err = s.repo.WithTransaction(ctx, func(tx *sql.Tx) error {
 		var err error
 		book, err = s.repo.GetBook(ctx, req.Id)
 		if err != nil {
 			return fmt.Errorf("failed to get book: %w", err)
 		}
 
-		book.Title = req.Title
-		book.AuthorID = req.AuthorId
-		book.ISBN = req.Isbn
-		book.PublicationYear = int(req.PublicationYear)
-		book.Publisher = req.Publisher
-		book.Description = req.Description
-		book.TotalCopies = int(req.TotalCopies)
+		if book.Version != version {
+			return ErrVersionMismatch
+		}
+
+		if fields["title"] {
+			book.Title = req.Title
+		}
+		if fields["author_id"] {
+			book.AuthorID = req.AuthorId
+		}
+		if fields["isbn"] {
+			book.ISBN = req.Isbn
+		}
+		if fields["publication_year"] {
+			book.PublicationYear = int(req.PublicationYear)
+		}
+		if fields["publisher"] {
+			book.Publisher = req.Publisher
+		}
+		if fields["description"] {
+			book.Description = req.Description
+		}
+		if fields["total_copies"] {
+			book.TotalCopies = int(req.TotalCopies)
+		}
 
 		err = s.repo.UpdateBook(ctx, tx, book)
 		if err != nil {
 			return fmt.Errorf("failed to update book: %w", err)
 		}
 
-		_, err = s.categoryService.UpdateItemCategories(ctx, &category_pb.UpdateItemCategoriesRequest{
-			ItemId:      book.ID,
-			ItemType:    "book",
-			CategoryIds: req.CategoryIds,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to update categories: %w", err)
+		// Categories are only replaced when requested, a partial update keeps them
+		if fields["category_ids"] {
+			_, err = s.categoryService.UpdateItemCategories(ctx, &category_pb.UpdateItemCategoriesRequest{
+				ItemId:      book.ID,
+				ItemType:    "book",
+				CategoryIds: req.CategoryIds,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update categories: %w", err)
+			}
 		}
 
 		return nil
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update book: %v", err)
+		return nil, apperror.ToStatus(err, "failed to update book")
+	}
+
+	resp, err := s.bookToProto(ctx, book)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to convert book to proto")
 	}
 
-	return s.bookToProto(ctx, book)
+	grpcprotocol.SetETag(ctx, book.Version)
+	return resp, nil
 }
 
 func (s *Service) DeleteBook(ctx context.Context, req *pb.DeleteBookRequest) (*pb.DeleteBookResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// Categories are kept so a restored book gets them back, they are removed when the book is purged
 	err := s.repo.WithTransaction(ctx, func(tx *sql.Tx) error {
 		err := s.repo.DeleteBook(ctx, tx, req.Id)
 		if err != nil {
 			return fmt.Errorf("failed to delete book: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to delete book")
+	}
 
-		_, err = s.categoryService.UpdateItemCategories(ctx, &category_pb.UpdateItemCategoriesRequest{
-			ItemId:      req.Id,
+	return &pb.DeleteBookResponse{Success: true}, nil
+}
+
+func (s *Service) RestoreBook(ctx context.Context, req *pb.RestoreBookRequest) (*pb.BookResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RestoreBook(ctx, req.Id); err != nil {
+		return nil, apperror.ToStatus(err, "failed to restore book")
+	}
+
+	book, err := s.repo.GetBook(ctx, req.Id)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to get book")
+	}
+
+	resp, err := s.bookToProto(ctx, book)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to convert book to proto")
+	}
+
+	grpcprotocol.SetETag(ctx, book.Version)
+	return resp, nil
+}
+
+func (s *Service) ListDeletedBooks(ctx context.Context, req *pb.ListDeletedBooksRequest) (*pb.ListBooksResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	books, total, err := s.repo.ListDeletedBooks(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list deleted books")
+	}
+
+	pbBooks := make([]*pb.BookSummary, len(books))
+	for i, book := range books {
+		pbBook, err := s.bookSummaryToProto(ctx, book)
+		if err != nil {
+			return nil, apperror.ToStatus(err, "failed to convert book to proto")
+		}
+		pbBooks[i] = pbBook
+	}
+
+	return &pb.ListBooksResponse{
+		Books: pbBooks,
+		Total: int32(total),
+	}, nil
+}
+
+// PurgeDeletedBooks permanently removes books deleted before the given time together with their
+// categories and returns how many were removed. The categories go first: a book whose
+// categories cannot be removed is kept, so the next run tries again.
+func (s *Service) PurgeDeletedBooks(ctx context.Context, before time.Time) (int, error) {
+	ids, err := s.repo.ListPurgeableBooks(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		_, err := s.categoryService.UpdateItemCategories(ctx, &category_pb.UpdateItemCategoriesRequest{
+			ItemId:      id,
 			ItemType:    "book",
 			CategoryIds: []string{}, // Empty array to remove all categories
 		})
 		if err != nil {
-			return fmt.Errorf("failed to remove categories: %w", err)
+			return purged, fmt.Errorf("failed to remove categories of book %s: %w", id, err)
 		}
 
-		return nil
-	})
+		found, err := s.repo.PurgeBook(ctx, id, before)
+		if err != nil {
+			return purged, err
+		}
+		if found {
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// ListReferencedAuthors tells the author service which authors it must not purge
+func (s *Service) ListReferencedAuthors(ctx context.Context, req *pb.ListReferencedAuthorsRequest) (*pb.ListReferencedAuthorsResponse, error) {
+	ids, err := s.repo.ListReferencedAuthors(ctx, req.AuthorIds)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete book: %v", err)
+		return nil, apperror.ToStatus(err, "failed to list referenced authors")
 	}
 
-	return &pb.DeleteBookResponse{Success: true}, nil
+	return &pb.ListReferencedAuthorsResponse{AuthorIds: ids}, nil
 }
 
 func (s *Service) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
 	params := ListBooksParams{
-		Page:                 int(req.Page),
-		PageSize:             int(req.PageSize),
+		Page:                 page,
+		PageSize:             pageSize,
 		TitleQuery:           req.TitleQuery,
 		AuthorQuery:          req.AuthorQuery,
 		ISBNQuery:            req.IsbnQuery,
@@ -160,14 +320,14 @@ func (s *Service) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.
 
 	books, total, err := s.repo.ListBooks(ctx, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list books: %v", err)
+		return nil, apperror.ToStatus(err, "failed to list books")
 	}
 
 	pbBooks := make([]*pb.BookSummary, len(books))
 	for i, book := range books {
 		pbBook, err := s.bookSummaryToProto(ctx, book)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to convert book to proto: %v", err)
+			return nil, apperror.ToStatus(err, "failed to convert book to proto")
 		}
 		pbBooks[i] = pbBook
 	}
@@ -189,11 +349,9 @@ func (s *Service) BorrowBook(ctx context.Context, req *pb.BorrowBookRequest) (*p
 		return nil
 	})
 	if err != nil {
-		if err == ErrNoAvailableCopies {
-			return nil, status.Errorf(codes.FailedPrecondition, "no available copies")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to borrow book: %v", err)
+		return nil, apperror.ToStatus(err, "failed to borrow book")
 	}
+	booksBorrowed.Inc()
 
 	return &pb.BorrowBookResponse{
 		Success:       true,
@@ -210,8 +368,9 @@ func (s *Service) ReturnBook(ctx context.Context, req *pb.ReturnBookRequest) (*p
 		return nil
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to return book: %v", err)
+		return nil, apperror.ToStatus(err, "failed to return book")
 	}
+	booksReturned.Inc()
 
 	return &pb.ReturnBookResponse{Success: true}, nil
 }
@@ -223,7 +382,7 @@ func (s *Service) GetBookRecommendations(ctx context.Context, req *pb.GetBookRec
 		ItemType: "book",
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get book categories: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get book categories")
 	}
 
 	categoryIds := make([]string, len(categories.Categories))
@@ -237,19 +396,24 @@ func (s *Service) GetBookRecommendations(ctx context.Context, req *pb.GetBookRec
 		ItemType:    "book",
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get related books: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get related books")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultRecommendationLimit
 	}
 
-	recommendations, err := s.repo.GetBookRecommendations(ctx, req.Id, relatedBooks.ItemIds, int(req.Limit))
+	recommendations, err := s.repo.GetBookRecommendations(ctx, req.Id, relatedBooks.ItemIds, limit)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get book recommendations: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get book recommendations")
 	}
 
 	pbRecommendations := make([]*pb.BookSummary, len(recommendations))
 	for i, book := range recommendations {
 		pbBook, err := s.bookSummaryToProto(ctx, book)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to convert book to proto: %v", err)
+			return nil, apperror.ToStatus(err, "failed to convert book to proto")
 		}
 		pbRecommendations[i] = pbBook
 	}
@@ -260,7 +424,7 @@ func (s *Service) GetBookRecommendations(ctx context.Context, req *pb.GetBookRec
 }
 
 func (s *Service) bookToProto(ctx context.Context, book *Book) (*pb.BookResponse, error) {
-	author, err := s.authorService.GetAuthor(ctx, &author_pb.GetAuthorRequest{Id: book.AuthorID})
+	author, err := s.authorService.GetAuthor(ctx, &author_pb.GetAuthorRequest{Id: book.AuthorID, IncludeDeleted: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get author: %w", err)
 	}
@@ -289,12 +453,14 @@ func (s *Service) bookToProto(ctx context.Context, book *Book) (*pb.BookResponse
 			Categories:      categoriesSummary,
 			CreatedAt:       timestamppb.New(book.CreatedAt),
 			UpdatedAt:       timestamppb.New(book.UpdatedAt),
+			Version:         book.Version,
+			DeletedAt:       timestampOrNil(book.DeletedAt),
 		},
 	}, nil
 }
 
 func (s *Service) bookSummaryToProto(ctx context.Context, book *Book) (*pb.BookSummary, error) {
-	author, err := s.authorService.GetAuthor(ctx, &author_pb.GetAuthorRequest{Id: book.AuthorID})
+	author, err := s.authorService.GetAuthor(ctx, &author_pb.GetAuthorRequest{Id: book.AuthorID, IncludeDeleted: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get author: %w", err)
 	}
@@ -303,13 +469,12 @@ func (s *Service) bookSummaryToProto(ctx context.Context, book *Book) (*pb.BookS
 		ItemId:   book.ID,
 		ItemType: "book",
 	})
-
-	categoriesSummary := categoriesToSummaries(categories.Categories)
-
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories: %w", err)
 	}
 
+	categoriesSummary := categoriesToSummaries(categories.Categories)
+
 	return &pb.BookSummary{
 		Id:    book.ID,
 		Title: book.Title,
@@ -325,9 +490,18 @@ func (s *Service) bookSummaryToProto(ctx context.Context, book *Book) (*pb.BookS
 		Categories:      categoriesSummary,
 		CreatedAt:       timestamppb.New(book.CreatedAt),
 		UpdatedAt:       timestamppb.New(book.UpdatedAt),
+		Version:         book.Version,
+		DeletedAt:       timestampOrNil(book.DeletedAt),
 	}, nil
 }
 
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
 func categoriesToSummaries(categories []*category_pb.Category) []*pb.CategorySummary {
 	categorySummaries := make([]*pb.CategorySummary, len(categories))
 	for i, category := range categories {
@@ -0,0 +1,52 @@
+package category
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/purnasatria/library-management/api/gen/category"
+	"github.com/purnasatria/library-management/internal/audit"
+)
+
+const auditEntityType = "category"
+
+// AuditMethods describes how the mutating CategoryService RPCs are audited. Item assignments are
+// audited with the items themselves, e.g. as part of a book's categories.
+func (s *Service) AuditMethods() map[string]audit.Method {
+	method := func(action string, entityID func(req, resp interface{}) string) audit.Method {
+		return audit.Method{
+			Action:     action,
+			EntityType: auditEntityType,
+			EntityID:   entityID,
+			Snapshot:   s.snapshot,
+		}
+	}
+
+	return map[string]audit.Method{
+		pb.CategoryService_CreateCategory_FullMethodName:  method(audit.ActionCreate, createdCategoryID),
+		pb.CategoryService_UpdateCategory_FullMethodName:  method(audit.ActionUpdate, audit.IDFromRequest),
+		pb.CategoryService_DeleteCategory_FullMethodName:  method(audit.ActionDelete, audit.IDFromRequest),
+		pb.CategoryService_RestoreCategory_FullMethodName: method(audit.ActionRestore, audit.IDFromRequest),
+	}
+}
+
+func (s *Service) snapshot(ctx context.Context, id string) (proto.Message, error) {
+	category, err := s.repo.GetCategory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.categoryToProto(category)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Category, nil
+}
+
+func createdCategoryID(req, resp interface{}) string {
+	if r, ok := resp.(*pb.CategoryResponse); ok {
+		return r.GetCategory().GetId()
+	}
+	return ""
+}
@@ -2,19 +2,29 @@ package category
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/purnasatria/library-management/pkg/apperror"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	ErrCategoryNotFound        = apperror.NotFound("category not found")
+	ErrDeletedCategoryNotFound = apperror.NotFound("deleted category not found")
+	ErrVersionMismatch         = apperror.Aborted("category has been modified by another request")
+)
+
 type Category struct {
 	ID          string
 	Name        string
 	Description string
+	Version     int32
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   *time.Time
 }
 
 type Repository struct {
@@ -29,11 +39,12 @@ func (r *Repository) CreateCategory(category *Category) error {
 	category.ID = uuid.New().String()
 	category.CreatedAt = time.Now()
 	category.UpdatedAt = time.Now()
+	category.Version = 1
 
 	_, err := r.db.Exec(`
-		INSERT INTO categories (id, name, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`, category.ID, category.Name, category.Description, category.CreatedAt, category.UpdatedAt)
+		INSERT INTO categories (id, name, description, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, category.ID, category.Name, category.Description, category.Version, category.CreatedAt, category.UpdatedAt)
 
 	return err
 }
@@ -41,11 +52,14 @@ func (r *Repository) CreateCategory(category *Category) error {
 func (r *Repository) GetCategory(id string) (*Category, error) {
 	category := &Category{}
 	err := r.db.QueryRow(`
-		SELECT id, name, description, created_at, updated_at
+		SELECT id, name, description, version, created_at, updated_at, deleted_at
 		FROM categories
-		WHERE id = $1
-	`, id).Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt, &category.UpdatedAt)
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id).Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCategoryNotFound
+		}
 		return nil, err
 	}
 
@@ -55,27 +69,89 @@ func (r *Repository) GetCategory(id string) (*Category, error) {
 func (r *Repository) UpdateCategory(category *Category) error {
 	category.UpdatedAt = time.Now()
 
-	_, err := r.db.Exec(`
+	// The update only applies if nobody changed the category since it was read (category.Version)
+	err := r.db.QueryRow(`
 		UPDATE categories
-		SET name = $2, description = $3, updated_at = $4
-		WHERE id = $1
-	`, category.ID, category.Name, category.Description, category.UpdatedAt)
+		SET name = $2, description = $3, updated_at = $4, version = version + 1
+		WHERE id = $1 AND version = $5 AND deleted_at IS NULL
+		RETURNING version
+	`, category.ID, category.Name, category.Description, category.UpdatedAt, category.Version).Scan(&category.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrVersionMismatch
+		}
+		return err
+	}
 
-	return err
+	return nil
 }
 
+// DeleteCategory only marks the category as deleted so it can be restored together with its
+// items, PurgeDeletedCategories removes it
 func (r *Repository) DeleteCategory(id string) error {
-	_, err := r.db.Exec("DELETE FROM categories WHERE id = $1", id)
-	return err
+	result, err := r.db.Exec(`
+		UPDATE categories
+		SET deleted_at = $2, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
+
+func (r *Repository) RestoreCategory(id string) error {
+	result, err := r.db.Exec(`
+		UPDATE categories
+		SET deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrDeletedCategoryNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedCategories permanently removes categories deleted before the given time, their
+// items are removed with them
+func (r *Repository) PurgeDeletedCategories(before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM categories WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(rows), nil
 }
 
 func (r *Repository) ListCategories(offset, limit int) ([]*Category, int, error) {
+	return r.listCategories(false, offset, limit)
+}
+
+func (r *Repository) ListDeletedCategories(offset, limit int) ([]*Category, int, error) {
+	return r.listCategories(true, offset, limit)
+}
+
+func (r *Repository) listCategories(deleted bool, offset, limit int) ([]*Category, int, error) {
 	rows, err := r.db.Query(`
-		SELECT id, name, description, created_at, updated_at
+		SELECT id, name, description, version, created_at, updated_at, deleted_at
 		FROM categories
-		ORDER BY created_at DESC
+		WHERE (deleted_at IS NOT NULL) = $3
+		ORDER BY COALESCE(deleted_at, created_at) DESC
 		LIMIT $1 OFFSET $2
-	`, limit, offset)
+	`, limit, offset, deleted)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -84,7 +160,7 @@ func (r *Repository) ListCategories(offset, limit int) ([]*Category, int, error)
 	var categories []*Category
 	for rows.Next() {
 		category := &Category{}
-		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt, &category.UpdatedAt)
+		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -93,7 +169,7 @@ func (r *Repository) ListCategories(offset, limit int) ([]*Category, int, error)
 	log.Debug().Any("data", categories)
 
 	var total int
-	err = r.db.QueryRow("SELECT COUNT(*) FROM categories").Scan(&total)
+	err = r.db.QueryRow("SELECT COUNT(*) FROM categories WHERE (deleted_at IS NOT NULL) = $1", deleted).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -201,10 +277,10 @@ func (r *Repository) BulkAddItemToCategories(itemID, itemType string, categoryID
 
 func (r *Repository) GetItemCategories(itemID, itemType string) ([]*Category, error) {
 	rows, err := r.db.Query(`
-		SELECT c.id, c.name, c.description, c.created_at, c.updated_at
+		SELECT c.id, c.name, c.description, c.version, c.created_at, c.updated_at, c.deleted_at
 		FROM categories c
 		JOIN category_items ci ON c.id = ci.category_id
-		WHERE ci.item_id = $1 AND ci.item_type = $2
+		WHERE ci.item_id = $1 AND ci.item_type = $2 AND c.deleted_at IS NULL
 	`, itemID, itemType)
 	if err != nil {
 		return nil, err
@@ -214,7 +290,7 @@ func (r *Repository) GetItemCategories(itemID, itemType string) ([]*Category, er
 	var categories []*Category
 	for rows.Next() {
 		category := &Category{}
-		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt, &category.UpdatedAt)
+		err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.Version, &category.CreatedAt, &category.UpdatedAt, &category.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -226,10 +302,12 @@ func (r *Repository) GetItemCategories(itemID, itemType string) ([]*Category, er
 
 func (r *Repository) GetItemsByCategories(categoryIDs []string, itemType string) ([]string, error) {
 	query := `
-		SELECT DISTINCT item_id
-		FROM category_items
-		WHERE category_id = ANY($1)
-		AND item_type = $2
+		SELECT DISTINCT ci.item_id
+		FROM category_items ci
+		JOIN categories c ON c.id = ci.category_id
+		WHERE ci.category_id = ANY($1)
+		AND ci.item_type = $2
+		AND c.deleted_at IS NULL
 	`
 
 	rows, err := r.db.Query(query, pq.Array(categoryIDs), itemType)
@@ -254,6 +332,17 @@ func (r *Repository) GetItemsByCategories(categoryIDs []string, itemType string)
 	return itemIDs, nil
 }
 
+func checkRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
 func difference(a, b []string) []string {
 	mb := make(map[string]struct{}, len(b))
 	for _, x := range b {
@@ -2,17 +2,21 @@ package category
 
 import (
 	"context"
-	"database/sql"
-	"errors"
+	"time"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/purnasatria/library-management/api/gen/category"
+	"github.com/purnasatria/library-management/pkg/apperror"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
 	"github.com/rs/zerolog/log"
 )
 
+const defaultPageSize = 10
+
+// updatableFields are the UpdateCategoryRequest fields an update_mask may name
+var updatableFields = []string{"name", "description"}
+
 type Service struct {
 	pb.UnimplementedCategoryServiceServer
 	repo *Repository
@@ -29,61 +33,143 @@ func (s *Service) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequ
 	}
 
 	if err := s.repo.CreateCategory(category); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create category: %v", err)
+		return nil, apperror.ToStatus(err, "failed to create category")
 	}
 
+	grpcprotocol.SetETag(ctx, category.Version)
 	return s.categoryToProto(category)
 }
 
 func (s *Service) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.CategoryResponse, error) {
 	category, err := s.repo.GetCategory(req.Id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "category not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get category: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get category")
 	}
 
+	grpcprotocol.SetETag(ctx, category.Version)
 	return s.categoryToProto(category)
 }
 
 func (s *Service) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.CategoryResponse, error) {
+	version, err := grpcprotocol.ExpectedVersion(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := grpcprotocol.UpdateFields(req.UpdateMask, updatableFields...)
+	if err != nil {
+		return nil, err
+	}
+
 	category, err := s.repo.GetCategory(req.Id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "category not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get category: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get category")
+	}
+
+	if category.Version != version {
+		return nil, ErrVersionMismatch
 	}
 
-	category.Name = req.Name
-	category.Description = req.Description
+	if fields["name"] {
+		category.Name = req.Name
+	}
+	if fields["description"] {
+		category.Description = req.Description
+	}
 
 	if err := s.repo.UpdateCategory(category); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update category: %v", err)
+		return nil, apperror.ToStatus(err, "failed to update category")
 	}
 
+	grpcprotocol.SetETag(ctx, category.Version)
 	return s.categoryToProto(category)
 }
 
 func (s *Service) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.DeleteCategory(req.Id); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Errorf(codes.NotFound, "category not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to delete category: %v", err)
+		return nil, apperror.ToStatus(err, "failed to delete category")
 	}
 
 	return &pb.DeleteCategoryResponse{Success: true}, nil
 }
 
+func (s *Service) RestoreCategory(ctx context.Context, req *pb.RestoreCategoryRequest) (*pb.CategoryResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RestoreCategory(req.Id); err != nil {
+		return nil, apperror.ToStatus(err, "failed to restore category")
+	}
+
+	category, err := s.repo.GetCategory(req.Id)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to get category")
+	}
+
+	grpcprotocol.SetETag(ctx, category.Version)
+	return s.categoryToProto(category)
+}
+
+func (s *Service) ListDeletedCategories(ctx context.Context, req *pb.ListDeletedCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	if err := grpcprotocol.RequireRole(ctx, grpcprotocol.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	offset := (page - 1) * limit
+
+	categories, total, err := s.repo.ListDeletedCategories(offset, limit)
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to list deleted categories")
+	}
+
+	pbCategories := make([]*pb.Category, len(categories))
+	for i, category := range categories {
+		pbCategory, err := s.categoryToProto(category)
+		if err != nil {
+			return nil, err
+		}
+		pbCategories[i] = pbCategory.Category
+	}
+
+	return &pb.ListCategoriesResponse{
+		Categories: pbCategories,
+		Total:      int32(total),
+	}, nil
+}
+
+// PurgeDeletedCategories permanently removes categories deleted before the given time and
+// returns how many were removed
+func (s *Service) PurgeDeletedCategories(ctx context.Context, before time.Time) (int, error) {
+	return s.repo.PurgeDeletedCategories(before)
+}
+
 func (s *Service) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
-	offset := int(req.Page-1) * int(req.PageSize)
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
 	limit := int(req.PageSize)
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	offset := (page - 1) * limit
 
 	categories, total, err := s.repo.ListCategories(offset, limit)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list categories: %v", err)
+		return nil, apperror.ToStatus(err, "failed to list categories")
 	}
 
 	pbCategories := make([]*pb.Category, len(categories))
@@ -105,7 +191,7 @@ func (s *Service) ListCategories(ctx context.Context, req *pb.ListCategoriesRequ
 func (s *Service) UpdateItemCategories(ctx context.Context, req *pb.UpdateItemCategoriesRequest) (*pb.UpdateItemCategoriesResponse, error) {
 	added, removed, err := s.repo.UpdateItemCategories(req.ItemId, req.ItemType, req.CategoryIds)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update item categories: %v", err)
+		return nil, apperror.ToStatus(err, "failed to update item categories")
 	}
 
 	return &pb.UpdateItemCategoriesResponse{
@@ -118,7 +204,7 @@ func (s *Service) UpdateItemCategories(ctx context.Context, req *pb.UpdateItemCa
 func (s *Service) BulkAddItemToCategories(ctx context.Context, req *pb.BulkAddItemToCategoriesRequest) (*pb.BulkAddItemToCategoriesResponse, error) {
 	err := s.repo.BulkAddItemToCategories(req.ItemId, req.ItemType, req.CategoryIds)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to bulk add item to categories: %v", err)
+		return nil, apperror.ToStatus(err, "failed to bulk add item to categories")
 	}
 
 	return &pb.BulkAddItemToCategoriesResponse{
@@ -129,7 +215,7 @@ func (s *Service) BulkAddItemToCategories(ctx context.Context, req *pb.BulkAddIt
 func (s *Service) GetItemCategories(ctx context.Context, req *pb.GetItemCategoriesRequest) (*pb.GetItemCategoriesResponse, error) {
 	categories, err := s.repo.GetItemCategories(req.ItemId, req.ItemType)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get item categories: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get item categories")
 	}
 
 	pbCategories := make([]*pb.Category, len(categories))
@@ -147,13 +233,9 @@ func (s *Service) GetItemCategories(ctx context.Context, req *pb.GetItemCategori
 }
 
 func (s *Service) GetItemsByCategories(ctx context.Context, req *pb.GetItemsByCategoriesRequest) (*pb.GetItemsByCategoriesResponse, error) {
-	if req.ItemType == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "item_type is required")
-	}
-
 	itemIDs, err := s.repo.GetItemsByCategories(req.CategoryIds, req.ItemType)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get items by categories: %v", err)
+		return nil, apperror.ToStatus(err, "failed to get items by categories")
 	}
 
 	return &pb.GetItemsByCategoriesResponse{
@@ -169,6 +251,15 @@ func (s *Service) categoryToProto(category *Category) (*pb.CategoryResponse, err
 			Description: category.Description,
 			CreatedAt:   timestamppb.New(category.CreatedAt),
 			UpdatedAt:   timestamppb.New(category.UpdatedAt),
+			Version:     category.Version,
+			DeletedAt:   timestampOrNil(category.DeletedAt),
 		},
 	}, nil
 }
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS revoked_access_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_revoked_at;
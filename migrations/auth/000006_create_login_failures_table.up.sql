@@ -0,0 +1,11 @@
+-- Failed logins per account, unknown username and IP address
+CREATE TABLE login_failures (
+    subject_type VARCHAR(10) NOT NULL CHECK (subject_type IN ('user', 'login', 'ip')),
+    subject VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    blocked_until TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (subject_type, subject)
+);
+
+CREATE INDEX idx_login_failures_blocked_until ON login_failures(blocked_until);
@@ -0,0 +1,10 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users GROUP BY lower(username) HAVING COUNT(*) > 1)
+        OR EXISTS (SELECT 1 FROM users GROUP BY lower(email) HAVING COUNT(*) > 1) THEN
+        RAISE EXCEPTION 'some users only differ in the case of their username or email, resolve them with cmd/userdupes first';
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX users_username_lower_key ON users(lower(username));
+CREATE UNIQUE INDEX users_email_lower_key ON users(lower(email));
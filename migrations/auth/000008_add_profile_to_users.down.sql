@@ -0,0 +1,6 @@
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
+ALTER TABLE users DROP COLUMN IF EXISTS address;
+ALTER TABLE users DROP COLUMN IF EXISTS phone;
+ALTER TABLE users DROP COLUMN IF EXISTS full_name;
+ALTER TABLE users DROP COLUMN IF EXISTS library_card_number;
+DROP SEQUENCE IF EXISTS library_card_number_seq;
@@ -0,0 +1,12 @@
+CREATE SEQUENCE IF NOT EXISTS library_card_number_seq;
+
+-- Existing users get a library card number as well
+ALTER TABLE users ADD COLUMN library_card_number VARCHAR(20) NOT NULL
+    DEFAULT ('LIB' || lpad(nextval('library_card_number_seq')::text, 8, '0'));
+ALTER TABLE users ADD CONSTRAINT users_library_card_number_key UNIQUE (library_card_number);
+ALTER SEQUENCE library_card_number_seq OWNED BY users.library_card_number;
+
+ALTER TABLE users ADD COLUMN full_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN phone VARCHAR(30) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMP WITH TIME ZONE;
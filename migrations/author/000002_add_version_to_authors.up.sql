@@ -0,0 +1 @@
+ALTER TABLE authors ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
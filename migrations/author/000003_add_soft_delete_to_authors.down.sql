@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_authors_deleted_at;
+ALTER TABLE authors DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE authors ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_authors_deleted_at ON authors(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_available_copies_check;
@@ -0,0 +1,2 @@
+UPDATE books SET available_copies = 0 WHERE available_copies < 0;
+ALTER TABLE books ADD CONSTRAINT books_available_copies_check CHECK (available_copies >= 0);
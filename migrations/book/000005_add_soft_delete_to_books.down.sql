@@ -0,0 +1,9 @@
+-- NOT VALID keeps the history of books purged in the meantime
+ALTER TABLE book_transactions ADD CONSTRAINT book_transactions_book_id_fkey
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE NOT VALID;
+
+DROP INDEX IF EXISTS books_isbn_key;
+ALTER TABLE books ADD CONSTRAINT books_isbn_key UNIQUE (isbn);
+
+DROP INDEX IF EXISTS idx_books_deleted_at;
+ALTER TABLE books DROP COLUMN IF EXISTS deleted_at;
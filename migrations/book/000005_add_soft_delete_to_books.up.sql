@@ -0,0 +1,10 @@
+ALTER TABLE books ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_books_deleted_at ON books(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- A deleted book frees its ISBN, restoring it fails while another book uses the ISBN
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_isbn_key;
+CREATE UNIQUE INDEX books_isbn_key ON books(isbn) WHERE deleted_at IS NULL;
+
+-- Circulation history is kept after a book is purged
+ALTER TABLE book_transactions DROP CONSTRAINT IF EXISTS book_transactions_book_id_fkey;
@@ -0,0 +1 @@
+ALTER TABLE categories ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
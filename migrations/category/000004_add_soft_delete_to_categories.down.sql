@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS categories_name_key;
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
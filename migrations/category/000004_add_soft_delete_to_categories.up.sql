@@ -0,0 +1,7 @@
+ALTER TABLE categories ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_categories_deleted_at ON categories(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- A deleted category frees its name, restoring it fails while another category uses the name
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_name_key;
+CREATE UNIQUE INDEX categories_name_key ON categories(name) WHERE deleted_at IS NULL;
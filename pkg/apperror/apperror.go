@@ -0,0 +1,193 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is reported in the ErrorInfo details of every status produced by this package
+const Domain = "library-management"
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqForeignKeyViolation       = "23503"
+	pqUniqueViolation           = "23505"
+	pqCheckViolation            = "23514"
+	pqInvalidTextRepresentation = "22P02"
+)
+
+// Error is a domain error that knows which gRPC status code it should be reported with.
+// Domain packages declare their errors with the constructors below, e.g.
+//
+//	var ErrBookNotFound = apperror.NotFound("book not found")
+type Error struct {
+	Code    codes.Code
+	Reason  string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// GRPCStatus lets the grpc runtime report the error with its code even when it is returned as is
+func (e *Error) GRPCStatus() *status.Status {
+	return withDetails(status.New(e.Code, e.Message), &errdetails.ErrorInfo{
+		Reason: e.Reason,
+		Domain: Domain,
+	})
+}
+
+// New creates a domain error. The reason is derived from the message, e.g. "book not found"
+// becomes "BOOK_NOT_FOUND".
+func New(code codes.Code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Reason:  reason(message),
+		Message: message,
+	}
+}
+
+func NotFound(message string) *Error {
+	return New(codes.NotFound, message)
+}
+
+func AlreadyExists(message string) *Error {
+	return New(codes.AlreadyExists, message)
+}
+
+func InvalidArgument(message string) *Error {
+	return New(codes.InvalidArgument, message)
+}
+
+func FailedPrecondition(message string) *Error {
+	return New(codes.FailedPrecondition, message)
+}
+
+func Unauthenticated(message string) *Error {
+	return New(codes.Unauthenticated, message)
+}
+
+func PermissionDenied(message string) *Error {
+	return New(codes.PermissionDenied, message)
+}
+
+func Aborted(message string) *Error {
+	return New(codes.Aborted, message)
+}
+
+func ResourceExhausted(message string) *Error {
+	return New(codes.ResourceExhausted, message)
+}
+
+func Unavailable(message string) *Error {
+	return New(codes.Unavailable, message)
+}
+
+// WithRetryDelay converts err into a gRPC status error that tells the client how long to wait
+// before trying again
+func WithRetryDelay(err *Error, delay time.Duration) error {
+	return withDetails(err.GRPCStatus(), &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}).Err()
+}
+
+// ToStatus converts err into a gRPC status error. Domain errors, PostgreSQL constraint
+// violations and statuses returned by downstream services keep their meaning anywhere in the
+// wrap chain; anything else is reported as Internal, prefixed with msg.
+func ToStatus(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.GRPCStatus().Err()
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if st := pqStatus(pqErr, msg); st != nil {
+			return st.Err()
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	// Errors returned by other services are already statuses
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		st := grpcErr.GRPCStatus()
+		if st.Code() != codes.Unknown && st.Code() != codes.Internal {
+			p := st.Proto()
+			p.Message = fmt.Sprintf("%s: %s", msg, st.Message())
+			return status.ErrorProto(p)
+		}
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+func pqStatus(err *pq.Error, msg string) *status.Status {
+	detail := err.Detail
+	if detail == "" {
+		detail = err.Message
+	}
+	message := fmt.Sprintf("%s: %s", msg, detail)
+
+	switch err.Code {
+	case pqUniqueViolation:
+		return withDetails(status.New(codes.AlreadyExists, message), &errdetails.ErrorInfo{
+			Reason: "ALREADY_EXISTS",
+			Domain: Domain,
+			Metadata: map[string]string{
+				"table":      err.Table,
+				"constraint": err.Constraint,
+			},
+		})
+	case pqForeignKeyViolation:
+		return withDetails(status.New(codes.FailedPrecondition, message), &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "FOREIGN_KEY",
+				Subject:     err.Constraint,
+				Description: detail,
+			}},
+		})
+	case pqCheckViolation, pqInvalidTextRepresentation:
+		return withDetails(status.New(codes.InvalidArgument, message), &errdetails.ErrorInfo{
+			Reason: "INVALID_VALUE",
+			Domain: Domain,
+			Metadata: map[string]string{
+				"table":      err.Table,
+				"constraint": err.Constraint,
+			},
+		})
+	}
+
+	return nil
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+func reason(message string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(message), "_"))
+}
@@ -1,15 +1,22 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Config struct {
@@ -19,8 +26,19 @@ type Config struct {
 	ConnMaxLifetime time.Duration
 }
 
+// NewConnection opens the database. Queries made with the context of a traced call are traced
+// as its children, others are not traced.
 func NewConnection(cfg *Config) (*sql.DB, error) {
-	db, err := sql.Open("postgres", cfg.URL)
+	db, err := otelsql.Open("postgres", cfg.URL,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return trace.SpanFromContext(ctx).SpanContext().IsValid()
+			},
+		}),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -55,3 +73,9 @@ func RunMigrations(db *sql.DB, migrationPath string) error {
 
 	return nil
 }
+
+// RegisterMetrics exports the connection pool statistics of db, as reported by db.Stats,
+// labelled with the database name
+func RegisterMetrics(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
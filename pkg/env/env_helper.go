@@ -49,3 +49,14 @@ func GetBool(key string, defaultValue bool) bool {
 	log.Warn().Str("key", key).Msg("Failed to parse env var as bool, using default")
 	return defaultValue
 }
+
+// GetFloat retrieves the value of the environment variable named by the key as a float.
+// If the variable is not present or cannot be parsed as a float, it returns the defaultValue.
+func GetFloat(key string, defaultValue float64) float64 {
+	valueStr := Get(key, "")
+	if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+		return value
+	}
+	log.Warn().Str("key", key).Msg("Failed to parse env var as float, using default")
+	return defaultValue
+}
@@ -1,75 +1,192 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type Config struct {
-	AccessTokenSecret          string
-	AccessTokenExpirationTime  time.Duration
+	// AccessTokenSecret signs access tokens with HS256 when no signing key is set
+	AccessTokenSecret         string
+	AccessTokenExpirationTime time.Duration
+	// Keys verify access tokens by their kid, the one with SigningKeyID signs new ones
+	Keys                       map[string]*Key
+	SigningKeyID               string
 	RefreshTokenSecret         string
 	RefreshTokenExpirationTime time.Duration
 }
 
+// ErrUnknownKey is returned for tokens signed with a key that is not known (yet)
+var ErrUnknownKey = errors.New("unknown key")
+
+// Claims are the claims of an access token
+type Claims struct {
+	Role string `json:"role,omitempty"`
+	// IssuedAtMicros is when the token was issued in microseconds since the epoch. iat only has
+	// seconds, too coarse to tell a token issued right after its user's tokens were revoked
+	// from one issued right before.
+	IssuedAtMicros int64 `json:"iat_us,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IssuedAtTime returns when the token was issued, zero if it does not say
+func (c *Claims) IssuedAtTime() time.Time {
+	if c.IssuedAtMicros != 0 {
+		return time.UnixMicro(c.IssuedAtMicros)
+	}
+	if c.IssuedAt != nil {
+		return c.IssuedAt.Time
+	}
+	return time.Time{}
+}
+
+// JWT issues and validates tokens. Refresh tokens are only used with the auth service and are
+// always signed with a shared secret.
 type JWT struct {
 	accessSecret          []byte
 	accessExpirationTime  time.Duration
+	signingKey            *Key
+	verificationKeys      map[string]*Key
 	refreshSecret         []byte
 	refreshExpirationTime time.Duration
 }
 
-func New(cfg *Config) *JWT {
-	return &JWT{
+func New(cfg *Config) (*JWT, error) {
+	j := &JWT{
 		accessSecret:          []byte(cfg.AccessTokenSecret),
 		accessExpirationTime:  cfg.AccessTokenExpirationTime,
+		verificationKeys:      cfg.Keys,
 		refreshSecret:         []byte(cfg.RefreshTokenSecret),
 		refreshExpirationTime: cfg.RefreshTokenExpirationTime,
 	}
+
+	if cfg.SigningKeyID != "" {
+		key, ok := cfg.Keys[cfg.SigningKeyID]
+		if !ok {
+			return nil, fmt.Errorf("signing key %q not found", cfg.SigningKeyID)
+		}
+		if key.Private == nil {
+			return nil, fmt.Errorf("signing key %q has no private key", cfg.SigningKeyID)
+		}
+		j.signingKey = key
+	}
+
+	return j, nil
 }
 
-func (j *JWT) GenerateAccessToken(userID string) (string, error) {
-	return j.generateToken(userID, j.accessSecret, j.accessExpirationTime)
+// AccessTokenExpirationTime returns how long access tokens are valid
+func (j *JWT) AccessTokenExpirationTime() time.Duration {
+	return j.accessExpirationTime
+}
+
+func (j *JWT) GenerateAccessToken(userID, role string) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		Role:             role,
+		IssuedAtMicros:   now.UnixMicro(),
+		RegisteredClaims: registeredClaims(userID, now, j.accessExpirationTime),
+	}
+
+	if j.signingKey == nil {
+		return j.generateToken(claims, j.accessSecret)
+	}
+
+	token := jwt.NewWithClaims(j.signingKey.Method, claims)
+	token.Header["kid"] = j.signingKey.ID
+	return token.SignedString(j.signingKey.Private)
 }
 
-func (j *JWT) GenerateRefreshToken(userID string) (string, error) {
-	return j.generateToken(userID, j.refreshSecret, j.refreshExpirationTime)
+// GenerateRefreshToken creates a refresh token, it only carries the user ID so the role is
+// read again when it is exchanged
+func (j *JWT) GenerateRefreshToken(userID string) (string, time.Time, error) {
+	claims := registeredClaims(userID, time.Now(), j.refreshExpirationTime)
+
+	token, err := j.generateToken(&claims, j.refreshSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, claims.ExpiresAt.Time, nil
 }
 
-func (j *JWT) generateToken(userID string, secret []byte, expirationTime time.Duration) (string, error) {
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expirationTime)),
+// registeredClaims gives every token a unique ID (jti) so it can be told apart and revoked
+func registeredClaims(userID string, now time.Time, expirationTime time.Duration) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		ID:        uuid.New().String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(expirationTime)),
 		Subject:   userID,
 	}
+}
 
+func (j *JWT) generateToken(claims jwt.Claims, secret []byte) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(secret)
 }
 
-func (j *JWT) ValidateAccessToken(tokenString string) (string, error) {
-	return j.validateToken(tokenString, j.accessSecret)
+func (j *JWT) ValidateAccessToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.accessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
+}
+
+// accessKey returns the key an access token is verified with: the verification key named by
+// its kid, or the shared secret for tokens without a kid
+func (j *JWT) accessKey(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(j.accessSecret) == 0 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return j.accessSecret, nil
+	}
+
+	key, ok := j.verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+
+	// The algorithm comes with the key, never from the token
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.Public, nil
 }
 
 func (j *JWT) ValidateRefreshToken(tokenString string) (string, error) {
-	return j.validateToken(tokenString, j.refreshSecret)
+	claims := &jwt.RegisteredClaims{}
+	if err := j.validateToken(tokenString, claims, j.refreshSecret); err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
 }
 
-func (j *JWT) validateToken(tokenString string, secret []byte) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+func (j *JWT) validateToken(tokenString string, claims jwt.Claims, secret []byte) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return secret, nil
 	})
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	if claims, ok := token.Claims.(*jwt.RegisteredClaims); ok && token.Valid {
-		return claims.Subject, nil
+	if !token.Valid {
+		return fmt.Errorf("invalid token")
 	}
 
-	return "", fmt.Errorf("invalid token")
+	return nil
 }
@@ -0,0 +1,349 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "access-secret"
+
+type testKeys struct {
+	ed25519 *Key
+	rsa     *Key
+	// rsaPEM is the PEM encoded public key of rsa, the bytes an attacker would sign HS256
+	// tokens with
+	rsaPEM []byte
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, err := newKey("ed", edPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := newKey("rsa", rsaPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaPrivate.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testKeys{
+		ed25519: edKey,
+		rsa:     rsaKey,
+		rsaPEM:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+	}
+}
+
+// sign signs claims for user-1 with method and key, adding kid to the header unless it is empty
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	claims := &Claims{Role: "user", RegisteredClaims: registeredClaims("user-1", time.Now(), time.Minute)}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestValidateAccessToken(t *testing.T) {
+	keys := newTestKeys(t)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := New(&Config{
+		AccessTokenSecret: testSecret,
+		Keys:              map[string]*Key{"ed": keys.ed25519, "rsa": keys.rsa},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+		valid   bool
+	}{
+		{
+			name:  "Ed25519 key by kid",
+			token: sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519.Private),
+			valid: true,
+		},
+		{
+			name:  "RSA key by kid",
+			token: sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa.Private),
+			valid: true,
+		},
+		{
+			name:  "shared secret without kid",
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret)),
+			valid: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   sign(t, jwt.SigningMethodEdDSA, "unknown", otherKey),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:  "kid signed with another key",
+			token: sign(t, jwt.SigningMethodEdDSA, "ed", otherKey),
+		},
+		{
+			name:  "HS256 signed with the RSA public key",
+			token: sign(t, jwt.SigningMethodHS256, "rsa", keys.rsaPEM),
+		},
+		{
+			name:  "HS256 signed with the Ed25519 public key",
+			token: sign(t, jwt.SigningMethodHS256, "ed", []byte(keys.ed25519.Public.(ed25519.PublicKey))),
+		},
+		{
+			name:  "HS256 signed with the shared secret and a kid",
+			token: sign(t, jwt.SigningMethodHS256, "ed", []byte(testSecret)),
+		},
+		{
+			name:  "RS256 with the kid of an Ed25519 key",
+			token: sign(t, jwt.SigningMethodRS256, "ed", keys.rsa.Private),
+		},
+		{
+			name:  "asymmetric key without kid",
+			token: sign(t, jwt.SigningMethodEdDSA, "", keys.ed25519.Private),
+		},
+		{
+			name:  "wrong shared secret",
+			token: sign(t, jwt.SigningMethodHS256, "", []byte("other-secret")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := j.ValidateAccessToken(tt.token)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("ValidateAccessToken() error = %v", err)
+				}
+				if claims.Subject != "user-1" || claims.Role != "user" {
+					t.Errorf("ValidateAccessToken() claims = %+v", claims)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("ValidateAccessToken() accepted the token")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateAccessToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAccessTokenWithoutSecret(t *testing.T) {
+	keys := newTestKeys(t)
+	j, err := New(&Config{Keys: map[string]*Key{"ed": keys.ed25519}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a shared secret, tokens without a kid must not verify against an empty HMAC key
+	if _, err := j.ValidateAccessToken(sign(t, jwt.SigningMethodHS256, "", []byte{})); err == nil {
+		t.Error("ValidateAccessToken() accepted an HS256 token without a configured secret")
+	}
+}
+
+func TestGenerateAccessToken(t *testing.T) {
+	keys := newTestKeys(t)
+
+	tests := []struct {
+		name         string
+		signingKeyID string
+		wantKid      string
+		wantAlg      string
+	}{
+		{name: "shared secret", wantAlg: "HS256"},
+		{name: "Ed25519", signingKeyID: "ed", wantKid: "ed", wantAlg: "EdDSA"},
+		{name: "RSA", signingKeyID: "rsa", wantKid: "rsa", wantAlg: "RS256"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := New(&Config{
+				AccessTokenSecret:         testSecret,
+				AccessTokenExpirationTime: time.Minute,
+				Keys:                      map[string]*Key{"ed": keys.ed25519, "rsa": keys.rsa},
+				SigningKeyID:              tt.signingKeyID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed, err := j.GenerateAccessToken("user-1", "admin")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, _, err := jwt.NewParser().ParseUnverified(signed, &Claims{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kid, _ := token.Header["kid"].(string); kid != tt.wantKid {
+				t.Errorf("kid = %q, want %q", kid, tt.wantKid)
+			}
+			if token.Method.Alg() != tt.wantAlg {
+				t.Errorf("alg = %q, want %q", token.Method.Alg(), tt.wantAlg)
+			}
+
+			claims, err := j.ValidateAccessToken(signed)
+			if err != nil {
+				t.Fatalf("ValidateAccessToken() error = %v", err)
+			}
+			if claims.Subject != "user-1" || claims.Role != "admin" {
+				t.Errorf("ValidateAccessToken() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestNewRejectsSigningKey(t *testing.T) {
+	keys := newTestKeys(t)
+	public, err := newKey("public", keys.ed25519.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		signingKeyID string
+	}{
+		{name: "unknown signing key", signingKeyID: "missing"},
+		{name: "public key only", signingKeyID: "public"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&Config{Keys: map[string]*Key{"public": public}, SigningKeyID: tt.signingKeyID})
+			if err == nil {
+				t.Error("New() error = nil")
+			}
+		})
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(edPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, "2024-01.pem"), "PRIVATE KEY", pkcs8)
+
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, "legacy.pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivate))
+
+	pkix, err := x509.MarshalPKIXPublicKey(&rsaPrivate.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, "verify-only.pem"), "PUBLIC KEY", pkix)
+
+	// Files without the .pem suffix are ignored
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kid        string
+		alg        string
+		hasPrivate bool
+	}{
+		{kid: "2024-01", alg: "EdDSA", hasPrivate: true},
+		{kid: "legacy", alg: "RS256", hasPrivate: true},
+		{kid: "verify-only", alg: "RS256"},
+	}
+
+	if len(keys) != len(tests) {
+		t.Fatalf("LoadKeys() returned %d keys, want %d", len(keys), len(tests))
+	}
+	for _, tt := range tests {
+		key, ok := keys[tt.kid]
+		if !ok {
+			t.Errorf("key %q not loaded", tt.kid)
+			continue
+		}
+		if key.ID != tt.kid || key.Method.Alg() != tt.alg || (key.Private != nil) != tt.hasPrivate {
+			t.Errorf("key %q = {ID: %q, alg: %q, private: %t}", tt.kid, key.ID, key.Method.Alg(), key.Private != nil)
+		}
+	}
+}
+
+func TestLoadKeysInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "no PEM data", data: []byte("not a key")},
+		{name: "unsupported block type", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}})},
+		{name: "corrupt key", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1, 2, 3}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "bad.pem"), tt.data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadKeys(dir); err == nil {
+				t.Error("LoadKeys() error = nil")
+			}
+		})
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,110 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type VerifierConfig struct {
+	// JWKSURL is the JWKS endpoint of the auth service, fetched every RefreshInterval
+	JWKSURL         string
+	RefreshInterval time.Duration
+	// AccessTokenSecret verifies access tokens signed with a shared secret
+	AccessTokenSecret string
+}
+
+// Verifier validates access tokens without calling the auth service. It only checks the
+// signature and expiry, it cannot tell whether a token has been revoked.
+type Verifier struct {
+	cfg    VerifierConfig
+	client *http.Client
+
+	mu  sync.RWMutex
+	jwt *JWT
+}
+
+// NewVerifier creates a verifier and, with a JWKS URL, keeps its keys up to date until ctx is
+// done. Tokens signed with keys it does not have yet fail with ErrUnknownKey.
+func NewVerifier(ctx context.Context, cfg VerifierConfig) *Verifier {
+	v := &Verifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		jwt:    &JWT{accessSecret: []byte(cfg.AccessTokenSecret)},
+	}
+
+	if cfg.JWKSURL != "" {
+		if err := v.refresh(ctx); err != nil {
+			log.Warn().Err(err).Str("url", cfg.JWKSURL).Msg("Failed to fetch JWKS, retrying later")
+		}
+		go v.run(ctx)
+	}
+
+	return v
+}
+
+func (v *Verifier) ValidateAccessToken(tokenString string) (*Claims, error) {
+	v.mu.RLock()
+	j := v.jwt
+	v.mu.RUnlock()
+
+	return j.ValidateAccessToken(tokenString)
+}
+
+func (v *Verifier) run(ctx context.Context) {
+	if v.cfg.RefreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(v.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := v.refresh(ctx); err != nil {
+				log.Warn().Err(err).Str("url", v.cfg.JWKSURL).Msg("Failed to refresh JWKS")
+			}
+		}
+	}
+}
+
+func (v *Verifier) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.jwt = &JWT{accessSecret: []byte(v.cfg.AccessTokenSecret), verificationKeys: keys}
+	v.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerifier(t *testing.T) {
+	keys := newTestKeys(t)
+
+	issuer, err := New(&Config{
+		AccessTokenSecret:         testSecret,
+		AccessTokenExpirationTime: time.Minute,
+		Keys:                      map[string]*Key{"ed": keys.ed25519, "rsa": keys.rsa},
+		SigningKeyID:              "ed",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(issuer.JWKSHandler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	v := NewVerifier(ctx, VerifierConfig{JWKSURL: server.URL, AccessTokenSecret: testSecret})
+
+	issued, err := issuer.GenerateAccessToken("user-1", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+		valid   bool
+	}{
+		{name: "token of the issuer", token: issued, valid: true},
+		{
+			name:  "other key of the JWKS",
+			token: sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa.Private),
+			valid: true,
+		},
+		{
+			name:  "shared secret without kid",
+			token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret)),
+			valid: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   sign(t, jwt.SigningMethodEdDSA, "rotated", keys.ed25519.Private),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:  "HS256 signed with the published RSA key",
+			token: sign(t, jwt.SigningMethodHS256, "rsa", keys.rsaPEM),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.ValidateAccessToken(tt.token)
+			if tt.valid {
+				if err != nil {
+					t.Errorf("ValidateAccessToken() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("ValidateAccessToken() accepted the token")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateAccessToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifierWithoutJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	v := NewVerifier(context.Background(), VerifierConfig{AccessTokenSecret: testSecret})
+
+	if _, err := v.ValidateAccessToken(sign(t, jwt.SigningMethodHS256, "", []byte(testSecret))); err != nil {
+		t.Errorf("ValidateAccessToken() error = %v for a token signed with the shared secret", err)
+	}
+
+	_, err := v.ValidateAccessToken(sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed25519.Private))
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("ValidateAccessToken() error = %v, want %v", err, ErrUnknownKey)
+	}
+}
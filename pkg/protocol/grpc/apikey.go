@@ -0,0 +1,153 @@
+package grpcprotocol
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+
+	pb_auth "github.com/purnasatria/library-management/api/gen/auth"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata of calls made with an API key. The key's scopes are set by the HTTP gateway once it
+// has verified the key.
+const (
+	apiKeyMetadata       = "x-api-key"
+	apiKeyScopesMetadata = "x-api-key-scopes"
+)
+
+const (
+	// RoleAPIKey is the role of calls made with an API key without the admin scope
+	RoleAPIKey = "api_key"
+	// ScopeAdmin lets an API key call admin-only methods within its other scopes
+	ScopeAdmin = "admin"
+)
+
+// maxCachedAPIKeys bounds the cache, expired entries are dropped once it is full
+const maxCachedAPIKeys = 1000
+
+// APIKey is a verified API key. Calls made with it are made on behalf of the key: its ID is
+// the user ID.
+type APIKey struct {
+	ID     string
+	Scopes []string
+}
+
+// Role is the role calls made with the key have
+func (k APIKey) Role() string {
+	for _, scope := range k.Scopes {
+		if scope == ScopeAdmin {
+			return RoleAdmin
+		}
+	}
+	return RoleAPIKey
+}
+
+// Allows reports whether the key may call a gRPC method
+func (k APIKey) Allows(fullMethod string) bool {
+	return hasScope(k.Scopes, fullMethod)
+}
+
+// RequiredScope returns the scope an API key needs to call a gRPC method: "<package>:read" for
+// Get and List methods and "<package>:write" for the others, e.g. "book:read" for
+// "/book.BookService/ListBooks". The write scope includes the read scope.
+func RequiredScope(fullMethod string) string {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	pkg := service
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		pkg = service[:i]
+	}
+
+	if strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List") {
+		return pkg + ":read"
+	}
+	return pkg + ":write"
+}
+
+func hasScope(scopes []string, fullMethod string) bool {
+	required := RequiredScope(fullMethod)
+	write := strings.TrimSuffix(required, ":read") + ":write"
+	for _, scope := range scopes {
+		if scope == required || scope == write {
+			return true
+		}
+	}
+	return false
+}
+
+type apiKeyEntry struct {
+	key       APIKey
+	valid     bool
+	expiresAt time.Time
+}
+
+// APIKeyVerifier verifies API keys with the auth service and caches its answers, so a revoked
+// key may still work for the cache TTL
+type APIKeyVerifier struct {
+	client   pb_auth.AuthServiceClient
+	cacheTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]apiKeyEntry
+}
+
+func NewAPIKeyVerifier(client pb_auth.AuthServiceClient, cacheTTL time.Duration) *APIKeyVerifier {
+	return &APIKeyVerifier{
+		client:   client,
+		cacheTTL: cacheTTL,
+		entries:  make(map[[sha256.Size]byte]apiKeyEntry),
+	}
+}
+
+// Verify returns the API key, or false if it is not valid. clientIP is the address of the client
+// that sent the key, the auth service locks it out after too many invalid keys.
+func (v *APIKeyVerifier) Verify(ctx context.Context, key, clientIP string) (APIKey, bool, error) {
+	hash := sha256.Sum256([]byte(key))
+
+	v.mu.Lock()
+	entry, ok := v.entries[hash]
+	v.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.key, entry.valid, nil
+	}
+
+	if clientIP != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, forwardedForMetadata, clientIP)
+	}
+	resp, err := v.client.VerifyAPIKey(ctx, &pb_auth.VerifyAPIKeyRequest{Key: key})
+	if err != nil {
+		return APIKey{}, false, err
+	}
+
+	entry = apiKeyEntry{
+		key:       APIKey{ID: resp.KeyId, Scopes: resp.Scopes},
+		valid:     resp.Valid,
+		expiresAt: time.Now().Add(v.cacheTTL),
+	}
+	if v.cacheTTL > 0 {
+		v.set(hash, entry)
+	}
+
+	return entry.key, entry.valid, nil
+}
+
+func (v *APIKeyVerifier) set(hash [sha256.Size]byte, entry apiKeyEntry) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.entries) >= maxCachedAPIKeys {
+		now := time.Now()
+		for h, e := range v.entries {
+			if now.After(e.expiresAt) {
+				delete(v.entries, h)
+			}
+		}
+		if len(v.entries) >= maxCachedAPIKeys {
+			v.entries = make(map[[sha256.Size]byte]apiKeyEntry)
+		}
+	}
+
+	v.entries[hash] = entry
+}
@@ -0,0 +1,47 @@
+package grpcprotocol
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// forwardedForMetadata carries the address of the client a gateway or service calls for
+const forwardedForMetadata = "x-forwarded-for"
+
+// forwarderKey marks calls from a gateway or service authenticated by its certificate or the
+// server key, which forward the address of the client they call for
+type forwarderKey struct{}
+
+// withForwarder marks a call as coming from an authenticated gateway or service
+func withForwarder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forwarderKey{}, true)
+}
+
+// ClientIP returns the address of the client a call comes from. For calls through the HTTP
+// gateway this is the last X-Forwarded-For entry, the one the gateway added itself; earlier
+// entries are set by the client and cannot be trusted. X-Forwarded-For is only read from
+// callers ServerKeyInterceptor authenticated as a gateway or service, any other caller could
+// set it to dodge a lockout, so for them it is the address of the connection.
+func ClientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && ctx.Value(forwarderKey{}) != nil {
+		if forwarded := md.Get(forwardedForMetadata); len(forwarded) > 0 {
+			entries := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,68 @@
+package grpcprotocol
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	etagMetadata    = "etag"
+	ifMatchMetadata = "if-match"
+	// The gateway forwards the HTTP If-Match header with its permanent header prefix
+	gatewayIfMatchMetadata = "grpcgateway-if-match"
+)
+
+// ErrVersionRequired is returned by ExpectedVersion when the client sent no version to update against
+var ErrVersionRequired = apperror.InvalidArgument("version is required")
+
+// IfMatchVersion returns the resource version sent by the client in the If-Match header,
+// either directly as gRPC metadata or forwarded by the HTTP gateway
+func IfMatchVersion(ctx context.Context) (int32, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	values := md.Get(ifMatchMetadata)
+	if len(values) == 0 {
+		values = md.Get(gatewayIfMatchMetadata)
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	// Accept "3", "\"3\"" and weak "W/\"3\"" entity tags
+	tag := strings.TrimPrefix(strings.TrimSpace(values[0]), "W/")
+	version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int32(version), true
+}
+
+// ExpectedVersion resolves the version an update is conditioned on: the version field of the
+// request if set, otherwise the If-Match header
+func ExpectedVersion(ctx context.Context, version int32) (int32, error) {
+	if version != 0 {
+		return version, nil
+	}
+	if version, ok := IfMatchVersion(ctx); ok {
+		return version, nil
+	}
+	return 0, ErrVersionRequired
+}
+
+// SetETag sends the resource version back to the client, the gateway exposes it as the ETag header
+func SetETag(ctx context.Context, version int32) {
+	if err := grpc.SetHeader(ctx, metadata.Pairs(etagMetadata, fmt.Sprintf(`"%d"`, version))); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("Failed to set etag header")
+	}
+}
@@ -0,0 +1,54 @@
+package grpcprotocol
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updateMasker is implemented by update requests that carry a google.protobuf.FieldMask
+type updateMasker interface {
+	GetUpdateMask() *fieldmaskpb.FieldMask
+}
+
+// UpdateFields resolves the fields an update request applies. Without a mask every updatable
+// field is applied, so full updates keep working; otherwise every path must name one of them.
+func UpdateFields(mask *fieldmaskpb.FieldMask, updatable ...string) (map[string]bool, error) {
+	fields := make(map[string]bool, len(updatable))
+	if len(mask.GetPaths()) == 0 {
+		for _, field := range updatable {
+			fields[field] = true
+		}
+		return fields, nil
+	}
+
+	allowed := make(map[string]bool, len(updatable))
+	for _, field := range updatable {
+		allowed[field] = true
+	}
+
+	for _, path := range mask.GetPaths() {
+		if !allowed[path] {
+			return nil, invalidUpdateMask(path)
+		}
+		fields[path] = true
+	}
+
+	return fields, nil
+}
+
+func invalidUpdateMask(path string) error {
+	description := fmt.Sprintf("field %q cannot be updated", path)
+	st := status.New(codes.InvalidArgument, "invalid request: update_mask: "+description)
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "update_mask", Description: description}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -21,7 +21,7 @@ func LogInterceptor(
 	resp, err := handler(ctx, req)
 
 	// Log details
-	logEvent := log.Debug().
+	logEvent := log.Ctx(ctx).Debug().
 		Dur("duration", time.Since(start)).
 		Str("method", info.FullMethod)
 
@@ -35,3 +35,25 @@ func LogInterceptor(
 
 	return resp, err
 }
+
+// LogStreamInterceptor is the LogInterceptor of streaming calls, it logs once the stream ends
+func LogStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	logEvent := log.Ctx(ss.Context()).Debug().
+		Dur("duration", time.Since(start)).
+		Str("method", info.FullMethod).
+		Bool("stream", true)
+
+	if err != nil {
+		logEvent = logEvent.Err(err).Str("status", status.Code(err).String())
+	} else {
+		logEvent = logEvent.Str("status", status.Code(nil).String())
+	}
+
+	logEvent.Send()
+
+	return err
+}
@@ -0,0 +1,50 @@
+package grpcprotocol
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Number of RPCs completed on the server, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	grpcHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time taken by the server to handle RPCs, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// MetricsInterceptor counts the calls of each method by status code and records how long they
+// take. It comes first in the chain, so calls rejected by the other interceptors are counted.
+func MetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+// MetricsStreamInterceptor is the MetricsInterceptor of streaming calls, it records once the
+// stream ends
+func MetricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeCall(info.FullMethod, start, err)
+	return err
+}
+
+func observeCall(fullMethod string, start time.Time, err error) {
+	// fullMethod is "/package.Service/Method"
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	grpcHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	grpcHandlingSeconds.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,43 @@
+package grpcprotocol
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptor turns a panicking handler into an Internal error, so one bad call does
+// not take the whole service down
+func RecoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, r, info.FullMethod)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor is the RecoveryInterceptor of streaming calls
+func RecoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), r, info.FullMethod)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func recovered(ctx context.Context, r interface{}, fullMethod string) error {
+	log.Ctx(ctx).Error().
+		Interface("panic", r).
+		Str("method", fullMethod).
+		Str("stack", string(debug.Stack())).
+		Msg("Recovered from panic")
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,93 @@
+package grpcprotocol
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDMetadata carries the ID of the originating request, the HTTP gateway forwards it
+// from the X-Request-ID header
+const requestIDMetadata = "x-request-id"
+
+// maxRequestIDLength bounds the request IDs accepted from clients
+const maxRequestIDLength = 128
+
+// ValidRequestID accepts IDs of letters, digits and the separators of common ID formats, so a
+// client cannot forge log lines or headers with its ID
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestIDFromContext returns the request ID sent by the caller, empty if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if ids := md.Get(requestIDMetadata); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// RequestIDInterceptor gives every call a request ID, the caller's if it is a sane one or a
+// new one otherwise, returns it in the x-request-id header and logs it on every line logged with the
+// call's context through log.Ctx. It comes before the interceptors that log.
+func RequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+// RequestIDStreamInterceptor is the RequestIDInterceptor of streaming calls
+func RequestIDStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	id := RequestIDFromContext(ctx)
+	if !ValidRequestID(id) {
+		id = uuid.New().String()
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Set(requestIDMetadata, id)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, id)); err != nil {
+		log.Debug().Err(err).Msg("Failed to set request ID header")
+	}
+
+	return log.With().Str("request_id", id).Logger().WithContext(ctx)
+}
+
+// ClientRequestIDInterceptor creates a client-side interceptor that sends the request ID of
+// the call being handled with the calls it makes to other services
+func ClientRequestIDInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadata, id)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// ClientRequestIDStreamInterceptor is the ClientRequestIDInterceptor of streaming calls
+func ClientRequestIDStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if id := RequestIDFromContext(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadata, id)
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
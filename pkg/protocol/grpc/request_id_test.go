@@ -0,0 +1,67 @@
+package grpcprotocol
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: "3f2b8c1e-7d4a-4e9b-a1c2-5d6e7f8a9b0c", want: true},
+		{id: "req_01HZX.trace:42", want: true},
+		{id: strings.Repeat("a", maxRequestIDLength), want: true},
+		{id: "", want: false},
+		{id: strings.Repeat("a", maxRequestIDLength+1), want: false},
+		{id: "id with spaces", want: false},
+		{id: "forged\nlog line", want: false},
+		{id: `{"level":"error"}`, want: false},
+		{id: "ünïcode", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidRequestID(tt.id); got != tt.want {
+			t.Errorf("ValidRequestID(%q) = %t, want %t", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		wantID string
+	}{
+		{name: "caller's ID", md: metadata.Pairs(requestIDMetadata, "abc-123"), wantID: "abc-123"},
+		{name: "no metadata"},
+		{name: "no ID", md: metadata.Pairs("other", "value")},
+		{name: "invalid ID", md: metadata.Pairs(requestIDMetadata, "bad\nid")},
+		{name: "too long ID", md: metadata.Pairs(requestIDMetadata, strings.Repeat("a", maxRequestIDLength+1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			id := RequestIDFromContext(withRequestID(ctx))
+			if tt.wantID != "" {
+				if id != tt.wantID {
+					t.Errorf("request ID = %q, want %q", id, tt.wantID)
+				}
+				return
+			}
+			if _, err := uuid.Parse(id); err != nil {
+				t.Errorf("request ID = %q, want a new UUID", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package grpcprotocol
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// DefaultServerKey is the server key of development setups, services refuse it elsewhere
+const DefaultServerKey = "default-server-key"
+
+// defaultServerKeyID is the ID of a server key given without one
+const defaultServerKeyID = "default"
+
+// ServerKeys are the server keys a service accepts, by key ID, and the one it sends. To rotate
+// without downtime, add the new key everywhere, make it the current key, then remove the old.
+type ServerKeys struct {
+	// hashes are compared instead of the keys, so comparisons take as long whatever the length
+	hashes    map[string][sha256.Size]byte
+	current   string
+	currentID string
+}
+
+// ParseServerKeys parses comma-separated "<id>:<key>" pairs; a key without an ID has the ID
+// "default". currentID names the key sent to other services, empty sends the first one. No
+// keys send and accept none, e.g. when the services identify each other by their certificates.
+func ParseServerKeys(spec, currentID string) (*ServerKeys, error) {
+	k := &ServerKeys{hashes: make(map[string][sha256.Size]byte)}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, key, ok := strings.Cut(entry, ":")
+		if !ok {
+			id, key = defaultServerKeyID, entry
+		}
+		if id == "" || key == "" {
+			return nil, fmt.Errorf("server key %q has no ID or no key", entry)
+		}
+		if _, ok := k.hashes[id]; ok {
+			return nil, fmt.Errorf("duplicate server key ID %q", id)
+		}
+
+		k.hashes[id] = sha256.Sum256([]byte(key))
+		if (currentID == "" && k.currentID == "") || id == currentID {
+			k.current, k.currentID = key, id
+		}
+	}
+
+	if currentID != "" && k.currentID != currentID {
+		return nil, fmt.Errorf("current server key ID %q is not among the server keys", currentID)
+	}
+
+	return k, nil
+}
+
+// Current returns the key sent to other services, empty if there is none
+func (k *ServerKeys) Current() string {
+	if k == nil {
+		return ""
+	}
+	return k.current
+}
+
+// CurrentID returns the ID of the key sent to other services
+func (k *ServerKeys) CurrentID() string {
+	if k == nil {
+		return ""
+	}
+	return k.currentID
+}
+
+// Len returns the number of accepted keys
+func (k *ServerKeys) Len() int {
+	return len(k.hashes)
+}
+
+// Match returns the ID of the accepted key equal to key. Every key is compared in constant
+// time, so the time taken reveals neither the key nor which one matched.
+func (k *ServerKeys) Match(key string) (string, bool) {
+	if k == nil || key == "" {
+		return "", false
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	var matched string
+	for id, h := range k.hashes {
+		if subtle.ConstantTimeCompare(hash[:], h[:]) == 1 {
+			matched = id
+		}
+	}
+	return matched, matched != ""
+}
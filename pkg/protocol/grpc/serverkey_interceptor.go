@@ -2,7 +2,10 @@ package grpcprotocol
 
 import (
 	"context"
+	"strings"
 
+	"github.com/purnasatria/library-management/pkg/apperror"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -11,27 +14,147 @@ import (
 
 const serverKeyMetadata = "server-key"
 
-// ServerKeyInterceptor creates a server-side interceptor that validates the server key
-func ServerKeyInterceptor(validServerKey string) grpc.UnaryServerInterceptor {
+// healthMethodPrefix is the prefix of the methods of the grpc.health.v1 service
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// ServerAuth configures how ServerKeyInterceptor authenticates callers
+type ServerAuth struct {
+	// ServerKeys are the keys shared by the services, nil accepts no server key
+	ServerKeys *ServerKeys
+	// Services lists the methods each service identified by its client certificate may call
+	Services ServiceAllowList
+	// APIKeys verifies the API keys of machine clients, nil accepts no API key
+	APIKeys *APIKeyVerifier
+}
+
+// ServerKeyInterceptor creates a server-side interceptor that authenticates the caller: a
+// service calling with its client certificate or the server key, or a machine client calling
+// with an API key in the x-api-key metadata. Calls made with an API key, directly or through
+// the HTTP gateway, are limited to its scopes.
+func ServerKeyInterceptor(auth ServerAuth) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+		ctx, err := auth.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		serverKeys := md.Get(serverKeyMetadata)
-		if len(serverKeys) == 0 || serverKeys[0] != validServerKey {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid server key")
+// ServerKeyStreamInterceptor is the ServerKeyInterceptor of streaming calls
+func ServerKeyStreamInterceptor(auth ServerAuth) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := auth.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
 		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		return handler(ctx, req)
+// authenticate returns the context the call is handled with
+func (auth ServerAuth) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	// Health checks come from orchestrators and load balancers, which have no key
+	if strings.HasPrefix(fullMethod, healthMethodPrefix) {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+	}
+
+	if service, ok := ServiceFromContext(ctx); ok {
+		if !auth.Services.Allows(service, fullMethod) {
+			return nil, status.Errorf(codes.PermissionDenied, "service %s may not call %s", service, fullMethod)
+		}
+		if err := checkForwardedScopes(md, fullMethod); err != nil {
+			return nil, err
+		}
+		return withForwarder(ctx), nil
+	}
+
+	if serverKeys := md.Get(serverKeyMetadata); len(serverKeys) > 0 {
+		if id, ok := auth.ServerKeys.Match(serverKeys[0]); ok {
+			logServerKey(ctx, auth.ServerKeys, id, fullMethod)
+			if err := checkForwardedScopes(md, fullMethod); err != nil {
+				return nil, err
+			}
+			return withForwarder(ctx), nil
+		}
+	}
+
+	keys := md.Get(apiKeyMetadata)
+	if len(keys) == 0 || auth.APIKeys == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid server key")
 	}
+
+	key, valid, err := auth.APIKeys.Verify(ctx, keys[0], ClientIP(ctx))
+	if err != nil {
+		return nil, apperror.ToStatus(err, "failed to verify API key")
+	}
+	if !valid {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid API key")
+	}
+	if !key.Allows(fullMethod) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key lacks scope %s", RequiredScope(fullMethod))
+	}
+
+	// The key is the caller, whatever user the client claims to be
+	md = md.Copy()
+	md.Set(userIDMetadata, key.ID)
+	md.Set(userRoleMetadata, key.Role())
+	md.Set(apiKeyScopesMetadata, strings.Join(key.Scopes, ","))
+	return metadata.NewIncomingContext(ctx, md), nil
+}
+
+// serverStream is a server stream handled with another context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// logServerKey logs the key a caller used, callers still using a previous key are the ones
+// left to update before it is removed
+func logServerKey(ctx context.Context, keys *ServerKeys, id, fullMethod string) {
+	logger := log.Ctx(ctx)
+	event := logger.Debug()
+	if id != keys.CurrentID() {
+		event = logger.Info()
+	}
+	event.Str("server_key_id", id).Str("method", fullMethod).Msg("Authenticated with server key")
+}
+
+// checkForwardedScopes limits calls the HTTP gateway forwards for an API key to its scopes
+func checkForwardedScopes(md metadata.MD, fullMethod string) error {
+	if scopes := md.Get(apiKeyScopesMetadata); len(scopes) > 0 && !hasScope(strings.Split(scopes[0], ","), fullMethod) {
+		return status.Errorf(codes.PermissionDenied, "API key lacks scope %s", RequiredScope(fullMethod))
+	}
+	return nil
 }
 
-// ClientServerKeyInterceptor creates a client-side interceptor that adds the server key to outgoing requests
+// ClientServerKeyInterceptor creates a client-side interceptor that adds the server key to
+// outgoing requests. An empty key is not sent, services then identify with their certificate.
 func ClientServerKeyInterceptor(serverKey string) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if serverKey == "" {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
 		ctx = metadata.AppendToOutgoingContext(ctx, serverKeyMetadata, serverKey)
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
+
+// ClientServerKeyStreamInterceptor is the ClientServerKeyInterceptor of streaming calls
+func ClientServerKeyStreamInterceptor(serverKey string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if serverKey != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, serverKeyMetadata, serverKey)
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,84 @@
+package grpcprotocol
+
+import "testing"
+
+func TestParseServerKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		currentID string
+		wantErr   bool
+		wantLen   int
+		wantID    string
+		wantKey   string
+	}{
+		{name: "empty", spec: "", wantLen: 0},
+		{name: "key without ID", spec: "secret", wantLen: 1, wantID: "default", wantKey: "secret"},
+		{name: "first key is current", spec: "new:k2, old:k1", wantLen: 2, wantID: "new", wantKey: "k2"},
+		{name: "named current key", spec: "new:k2,old:k1", currentID: "old", wantLen: 2, wantID: "old", wantKey: "k1"},
+		{name: "blank entries are skipped", spec: " ,a:k1,, ", wantLen: 1, wantID: "a", wantKey: "k1"},
+		{name: "key containing a colon", spec: "a:k:1", wantLen: 1, wantID: "a", wantKey: "k:1"},
+		{name: "missing ID", spec: ":k1", wantErr: true},
+		{name: "missing key", spec: "a:", wantErr: true},
+		{name: "duplicate ID", spec: "a:k1,a:k2", wantErr: true},
+		{name: "duplicate default ID", spec: "k1,k2", wantErr: true},
+		{name: "unknown current ID", spec: "a:k1", currentID: "b", wantErr: true},
+		{name: "current ID without keys", spec: "", currentID: "a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParseServerKeys(tt.spec, tt.currentID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseServerKeys(%q, %q) error = nil", tt.spec, tt.currentID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseServerKeys(%q, %q) error = %v", tt.spec, tt.currentID, err)
+			}
+
+			if keys.Len() != tt.wantLen {
+				t.Errorf("Len() = %d, want %d", keys.Len(), tt.wantLen)
+			}
+			if keys.CurrentID() != tt.wantID || keys.Current() != tt.wantKey {
+				t.Errorf("current = %q:%q, want %q:%q", keys.CurrentID(), keys.Current(), tt.wantID, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestServerKeysMatch(t *testing.T) {
+	keys, err := ParseServerKeys("current:new-key,previous:old-key", "current")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		keys   *ServerKeys
+		key    string
+		wantID string
+		wantOK bool
+	}{
+		{name: "current key", keys: keys, key: "new-key", wantID: "current", wantOK: true},
+		{name: "previous key", keys: keys, key: "old-key", wantID: "previous", wantOK: true},
+		{name: "wrong key", keys: keys, key: "other-key"},
+		{name: "prefix of a key", keys: keys, key: "new"},
+		{name: "key with a suffix", keys: keys, key: "new-key2"},
+		{name: "key ID instead of the key", keys: keys, key: "current"},
+		{name: "empty key", keys: keys, key: ""},
+		{name: "no keys", keys: &ServerKeys{}, key: "new-key"},
+		{name: "nil keys", keys: nil, key: "new-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := tt.keys.Match(tt.key)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("Match(%q) = %q, %t, want %q, %t", tt.key, id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
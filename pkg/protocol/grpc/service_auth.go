@@ -0,0 +1,44 @@
+package grpcprotocol
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ServiceAllowList maps the services calling over mutual TLS to the methods they may call:
+// full method names ("/author.AuthorService/GetAuthor"), every method of a service
+// ("/author.AuthorService/*") or every method ("*")
+type ServiceAllowList map[string][]string
+
+// Allows reports whether a service may call a gRPC method
+func (l ServiceAllowList) Allows(service, fullMethod string) bool {
+	for _, pattern := range l[service] {
+		if pattern == "*" || pattern == fullMethod {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceFromContext returns the service making the call, the common name of the client
+// certificate it presented. Calls without a verified certificate have none.
+func ServiceFromContext(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	name := info.State.VerifiedChains[0][0].Subject.CommonName
+	return name, name != ""
+}
@@ -0,0 +1,98 @@
+package grpcprotocol
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSConfig holds the certificate a service serves gRPC with and presents when calling other
+// services. Without a certificate gRPC runs in plaintext.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// CAFile holds the CA that signed the certificates of the services. It verifies servers
+	// and the certificates clients must present, which identify the calling service. Without
+	// it servers are verified with the system CAs and clients are not asked for a certificate.
+	CAFile string
+}
+
+// Enabled reports whether gRPC runs over TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// Mutual reports whether clients must identify with a certificate signed by the CA
+func (c TLSConfig) Mutual() bool {
+	return c.Enabled() && c.CAFile != ""
+}
+
+// ServerCredentials returns the transport credentials of the gRPC server. With a CA, clients
+// without a certificate signed by it cannot connect.
+func (c TLSConfig) ServerCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		cfg.ClientCAs, err = loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+// ClientCredentials returns the transport credentials for calling other services, presenting
+// the service's certificate
+func (c TLSConfig) ClientCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		cfg.RootCAs, err = loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in CA file %s", file)
+	}
+	return pool, nil
+}
@@ -0,0 +1,62 @@
+package grpcprotocol
+
+import (
+	"context"
+
+	"github.com/purnasatria/library-management/pkg/apperror"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata set by the HTTP gateway once the caller's token has been verified
+const (
+	userIDMetadata   = "x-user-id"
+	userRoleMetadata = "x-user-role"
+)
+
+const RoleAdmin = "admin"
+
+var ErrPermissionDenied = apperror.PermissionDenied("permission denied")
+
+// User is the end user a call is made on behalf of
+type User struct {
+	ID   string
+	Role string
+}
+
+// UserFromContext returns the user a call is made on behalf of. Calls between services
+// carry no user.
+func UserFromContext(ctx context.Context) (User, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return User{}, false
+	}
+
+	ids := md.Get(userIDMetadata)
+	if len(ids) == 0 || ids[0] == "" {
+		return User{}, false
+	}
+
+	user := User{ID: ids[0]}
+	if roles := md.Get(userRoleMetadata); len(roles) > 0 {
+		user.Role = roles[0]
+	}
+
+	return user, true
+}
+
+// RequireRole rejects calls made on behalf of a user that has none of roles. Calls between
+// services are already authenticated by the server key and are allowed.
+func RequireRole(ctx context.Context, roles ...string) error {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, role := range roles {
+		if user.Role == role {
+			return nil
+		}
+	}
+
+	return ErrPermissionDenied
+}
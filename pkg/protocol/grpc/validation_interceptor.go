@@ -0,0 +1,163 @@
+package grpcprotocol
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validatorAll is implemented by messages generated with protoc-gen-validate
+type validatorAll interface {
+	ValidateAll() error
+}
+
+// multiError is implemented by the aggregated errors returned from ValidateAll
+type multiError interface {
+	AllErrors() []error
+}
+
+// fieldError is implemented by the per-message validation errors generated by protoc-gen-validate
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// ValidationInterceptor creates a server-side interceptor that validates requests against their
+// proto annotations and rejects invalid ones with InvalidArgument and BadRequest field violations.
+// Partial updates are not rejected for fields they leave empty and outside their update mask.
+func ValidationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// ValidationStreamInterceptor is the ValidationInterceptor of streaming calls, it validates
+// every message the client sends
+func ValidationStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss})
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+func validate(req interface{}) error {
+	v, ok := req.(validatorAll)
+	if !ok {
+		return nil
+	}
+
+	if err := v.ValidateAll(); err != nil {
+		violations := fieldViolations("", err)
+		if m, ok := req.(updateMasker); ok && len(m.GetUpdateMask().GetPaths()) > 0 {
+			violations = maskedViolations(req, m.GetUpdateMask().GetPaths(), violations)
+		}
+		if len(violations) > 0 {
+			return validationStatus(violations).Err()
+		}
+	}
+
+	return nil
+}
+
+func validationStatus(violations []*errdetails.BadRequest_FieldViolation) *status.Status {
+	st := status.New(codes.InvalidArgument, "invalid request")
+	if len(violations) > 0 {
+		st = status.New(codes.InvalidArgument, "invalid request: "+violations[0].Field+": "+violations[0].Description)
+	}
+
+	detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return st
+	}
+	return detailed
+}
+
+func fieldViolations(prefix string, err error) []*errdetails.BadRequest_FieldViolation {
+	var multi multiError
+	if errors.As(err, &multi) {
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, e := range multi.AllErrors() {
+			violations = append(violations, fieldViolations(prefix, e)...)
+		}
+		return violations
+	}
+
+	var fe fieldError
+	if !errors.As(err, &fe) {
+		return []*errdetails.BadRequest_FieldViolation{{Field: strings.TrimSuffix(prefix, "."), Description: err.Error()}}
+	}
+
+	field := prefix + snakeCase(fe.Field())
+
+	// Embedded messages report their own violations as the cause
+	if cause := fe.Cause(); cause != nil && (errors.As(cause, &multi) || errors.As(cause, new(fieldError))) {
+		return fieldViolations(field+".", cause)
+	}
+
+	return []*errdetails.BadRequest_FieldViolation{{Field: field, Description: fe.Reason()}}
+}
+
+// maskedViolations drops the violations of top-level fields that are unset and not named in paths
+func maskedViolations(req interface{}, paths []string, violations []*errdetails.BadRequest_FieldViolation) []*errdetails.BadRequest_FieldViolation {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return violations
+	}
+	m := msg.ProtoReflect()
+
+	masked := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		masked[path] = true
+	}
+
+	var kept []*errdetails.BadRequest_FieldViolation
+	for _, v := range violations {
+		name := v.Field
+		if i := strings.IndexAny(name, ".["); i >= 0 {
+			name = name[:i]
+		}
+
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd != nil && !masked[name] && !m.Has(fd) {
+			continue
+		}
+		kept = append(kept, v)
+	}
+
+	return kept
+}
+
+// snakeCase converts the Go field names reported by protoc-gen-validate (e.g. "CategoryIds[0]")
+// back to proto field names (e.g. "category_ids[0]")
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 && s[i-1] != '[' && s[i-1] != '.' {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package httpprotocol
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// AccessLogMiddleware logs every request once it is served, with the logger of the request's
+// context so the line carries its request ID. Requests to quietPaths, e.g. probes, are logged
+// at debug level.
+func AccessLogMiddleware(quietPaths ...string) func(http.Handler) http.Handler {
+	quiet := make(map[string]bool, len(quietPaths))
+	for _, path := range quietPaths {
+		quiet[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rw, r)
+
+			level := zerolog.InfoLevel
+			switch {
+			case rw.status >= http.StatusInternalServerError:
+				level = zerolog.ErrorLevel
+			case quiet[r.URL.Path]:
+				level = zerolog.DebugLevel
+			}
+
+			log.Ctx(r.Context()).WithLevel(level).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Int("status", rw.status).
+				Int64("bytes", rw.bytes).
+				Dur("duration", time.Since(start)).
+				Str("remote_addr", r.RemoteAddr).
+				Str("user_agent", r.UserAgent()).
+				Msg("HTTP request")
+		})
+	}
+}
+
+// responseWriter records the status code and size of a response
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
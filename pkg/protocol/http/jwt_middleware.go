@@ -2,50 +2,197 @@ package httpprotocol
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb_auth "github.com/purnasatria/library-management/api/gen/auth"
+	"github.com/purnasatria/library-management/pkg/jwt"
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
+)
+
+// Headers the gateway forwards to the gRPC services as x-user-id, x-user-role and
+// x-api-key-scopes metadata
+const (
+	userIDHeader       = "Grpc-Metadata-X-User-Id"
+	userRoleHeader     = "Grpc-Metadata-X-User-Role"
+	apiKeyScopesHeader = "Grpc-Metadata-X-Api-Key-Scopes"
 )
 
-func JWTAuthMiddleware(authClient pb_auth.AuthServiceClient, exemptPaths []string) func(http.Handler) http.Handler {
+// APIKeyHeader carries the API key of machine clients instead of a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// TokenVerifier validates access tokens locally, e.g. *jwt.Verifier
+type TokenVerifier interface {
+	ValidateAccessToken(token string) (*jwt.Claims, error)
+}
+
+type JWTAuthConfig struct {
+	AuthClient  pb_auth.AuthServiceClient
+	ExemptPaths []string
+	// Verifier verifies tokens without calling the auth service, which is only asked about
+	// tokens signed with keys the verifier does not know. Nil verifies every token with the
+	// auth service.
+	Verifier TokenVerifier
+	// Revocations are checked for every locally verified token. Nil accepts revoked tokens
+	// until they expire.
+	Revocations *Revocations
+	// CacheTTL is how long a verification is reused. The auth service's answer is not checked
+	// against the revocations again, so a revoked token may be accepted that long. Zero
+	// disables the cache.
+	CacheTTL time.Duration
+	// APIKeys verifies the X-API-Key header of machine clients, nil rejects API keys
+	APIKeys *grpcprotocol.APIKeyVerifier
+}
+
+func JWTAuthMiddleware(cfg JWTAuthConfig) func(http.Handler) http.Handler {
+	cache := newTokenCache()
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Only the verified token may say who the caller is
+			r.Header.Del(userIDHeader)
+			r.Header.Del(userRoleHeader)
+			r.Header.Del(apiKeyScopesHeader)
+
 			// Check if the path is exempt
-			for _, exemptPath := range exemptPaths {
+			for _, exemptPath := range cfg.ExemptPaths {
 				if strings.HasPrefix(r.URL.Path, exemptPath) {
 					next.ServeHTTP(w, r)
 					return
 				}
 			}
 
+			// The gRPC services limit calls made with an API key to its scopes
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+				if cfg.APIKeys == nil {
+					httpError(w, r, "API keys are not accepted", http.StatusUnauthorized)
+					return
+				}
+
+				key, valid, err := cfg.APIKeys.Verify(r.Context(), apiKey, remoteIP(r))
+				if status.Code(err) == codes.ResourceExhausted {
+					http.Error(w, "Too many invalid API keys, try again later", http.StatusTooManyRequests)
+					return
+				}
+				if err != nil {
+					log.Ctx(r.Context()).Error().Err(err).Msg("Failed to verify API key")
+					httpError(w, r, "Failed to verify API key", http.StatusInternalServerError)
+					return
+				}
+				if !valid {
+					httpError(w, r, "Invalid API key", http.StatusUnauthorized)
+					return
+				}
+
+				r.Header.Set(userIDHeader, key.ID)
+				r.Header.Set(userRoleHeader, key.Role())
+				r.Header.Set(apiKeyScopesHeader, strings.Join(key.Scopes, ","))
+
+				ctx := context.WithValue(r.Context(), "user_id", key.ID)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				http.Error(w, "Missing authorization header", http.StatusUnauthorized)
+				httpError(w, r, "Missing authorization header", http.StatusUnauthorized)
 				return
 			}
 
 			bearerToken := strings.TrimPrefix(authHeader, "Bearer ")
 			if bearerToken == authHeader {
-				http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+				httpError(w, r, "Invalid authorization header format", http.StatusUnauthorized)
 				return
 			}
 
-			// Call the auth service to verify the token
-			resp, err := authClient.VerifyToken(r.Context(), &pb_auth.VerifyTokenRequest{Token: bearerToken})
-			if err != nil {
-				http.Error(w, "Failed to verify token", http.StatusInternalServerError)
-				return
+			result, ok := cache.get(bearerToken)
+			if !ok {
+				var err error
+				result, err = verifyToken(r.Context(), cfg, bearerToken)
+				if err != nil {
+					log.Ctx(r.Context()).Error().Err(err).Msg("Failed to verify token")
+					httpError(w, r, "Failed to verify token", http.StatusInternalServerError)
+					return
+				}
+				if cfg.CacheTTL > 0 && result.expiresAt.After(time.Now()) {
+					cache.set(bearerToken, result)
+				}
 			}
 
-			if !resp.Valid {
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
+			if result.local && cfg.Revocations != nil &&
+				cfg.Revocations.IsRevoked(result.tokenID, result.userID, result.issuedAt) {
+				result.valid = false
+			}
+
+			if !result.valid {
+				httpError(w, r, "Invalid token", http.StatusUnauthorized)
 				return
 			}
 
+			r.Header.Set(userIDHeader, result.userID)
+			r.Header.Set(userRoleHeader, result.role)
+
 			// Add user ID to the request context
-			ctx := context.WithValue(r.Context(), "user_id", resp.UserId)
+			ctx := context.WithValue(r.Context(), "user_id", result.userID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// verifyToken trusts the verifier's answer and only asks the auth service about tokens signed
+// with keys the verifier does not know, or every token without a verifier. Tokens the verifier
+// accepts before the revocations are fetched are checked by the auth service too.
+func verifyToken(ctx context.Context, cfg JWTAuthConfig, token string) (verification, error) {
+	expiresAt := time.Now().Add(cfg.CacheTTL)
+
+	if cfg.Verifier != nil {
+		claims, err := cfg.Verifier.ValidateAccessToken(token)
+		switch {
+		case err == nil:
+			if cfg.Revocations != nil && !cfg.Revocations.Loaded() {
+				break
+			}
+			if claims.ExpiresAt != nil && claims.ExpiresAt.Before(expiresAt) {
+				expiresAt = claims.ExpiresAt.Time
+			}
+			return verification{
+				valid:     true,
+				userID:    claims.Subject,
+				role:      claims.Role,
+				local:     true,
+				tokenID:   claims.ID,
+				issuedAt:  claims.IssuedAtTime(),
+				expiresAt: expiresAt,
+			}, nil
+		case !errors.Is(err, jwt.ErrUnknownKey):
+			return verification{valid: false}, nil
+		}
+	}
+
+	resp, err := cfg.AuthClient.VerifyToken(ctx, &pb_auth.VerifyTokenRequest{Token: token})
+	if err != nil {
+		return verification{}, err
+	}
+
+	return verification{
+		valid:     resp.Valid,
+		userID:    resp.UserId,
+		role:      resp.Role,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+// remoteIP returns the address of the client, the one the gateway forwards in X-Forwarded-For
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,150 @@
+package httpprotocol
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb_auth "github.com/purnasatria/library-management/api/gen/auth"
+	"github.com/purnasatria/library-management/pkg/jwt"
+)
+
+const testSecret = "test-access-secret"
+
+type fakeAuthClient struct {
+	pb_auth.AuthServiceClient
+	revocations  *pb_auth.ListRevocationsResponse
+	verifyCalls  int
+	verifyResult *pb_auth.VerifyTokenResponse
+}
+
+func (c *fakeAuthClient) VerifyToken(ctx context.Context, in *pb_auth.VerifyTokenRequest, opts ...grpc.CallOption) (*pb_auth.VerifyTokenResponse, error) {
+	c.verifyCalls++
+	return c.verifyResult, nil
+}
+
+func (c *fakeAuthClient) ListRevocations(ctx context.Context, in *pb_auth.ListRevocationsRequest, opts ...grpc.CallOption) (*pb_auth.ListRevocationsResponse, error) {
+	return c.revocations, nil
+}
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	issuer, err := jwt.New(&jwt.Config{AccessTokenSecret: testSecret, AccessTokenExpirationTime: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := jwt.NewVerifier(context.Background(), jwt.VerifierConfig{AccessTokenSecret: testSecret})
+
+	token := func(userID string) (string, *jwt.Claims) {
+		t.Helper()
+		token, err := issuer.GenerateAccessToken(userID, "member")
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, err := issuer.ValidateAccessToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token, claims
+	}
+
+	valid, _ := token("user-1")
+	revokedByID, revokedClaims := token("user-2")
+	revokedUser, revokedUserClaims := token("user-3")
+	suspendedUser, _ := token("user-5")
+
+	client := &fakeAuthClient{
+		verifyResult: &pb_auth.VerifyTokenResponse{Valid: true, UserId: "user-4", Role: "member"},
+		revocations: &pb_auth.ListRevocationsResponse{
+			TokenIds: []string{revokedClaims.ID},
+			Users: []*pb_auth.UserRevocation{{
+				UserId:        "user-3",
+				RevokedBefore: timestamppb.New(revokedUserClaims.IssuedAtTime()),
+			}, {
+				UserId: "user-5",
+			}},
+		},
+	}
+	handler := JWTAuthMiddleware(JWTAuthConfig{
+		AuthClient:  client,
+		Verifier:    verifier,
+		Revocations: NewRevocations(context.Background(), client, 0),
+		CacheTTL:    time.Minute,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get(userIDHeader)))
+	}))
+
+	tests := []struct {
+		name      string
+		token     string
+		wantCode  int
+		wantUser  string
+		wantCalls int
+	}{
+		{name: "locally verified", token: valid, wantCode: http.StatusOK, wantUser: "user-1"},
+		{name: "locally verified again from the cache", token: valid, wantCode: http.StatusOK, wantUser: "user-1"},
+		{name: "revoked token", token: revokedByID, wantCode: http.StatusUnauthorized},
+		{name: "token of a revoked user", token: revokedUser, wantCode: http.StatusUnauthorized},
+		{name: "token of a suspended user", token: suspendedUser, wantCode: http.StatusUnauthorized},
+		{name: "forged token", token: valid + "x", wantCode: http.StatusUnauthorized},
+		{
+			name:      "unknown key asks the auth service",
+			token:     signWithKid(t, "rotated"),
+			wantCode:  http.StatusOK,
+			wantUser:  "user-4",
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.verifyCalls = 0
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/books", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusOK && rec.Body.String() != tt.wantUser {
+				t.Errorf("user = %q, want %q", rec.Body.String(), tt.wantUser)
+			}
+			if client.verifyCalls != tt.wantCalls {
+				t.Errorf("VerifyToken calls = %d, want %d", client.verifyCalls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// signWithKid signs a token with a key the verifier does not know
+func signWithKid(t *testing.T, kid string) string {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &jwt.Key{ID: kid, Method: gojwt.SigningMethodEdDSA, Private: private, Public: public}
+	issuer, err := jwt.New(&jwt.Config{
+		AccessTokenExpirationTime: time.Minute,
+		Keys:                      map[string]*jwt.Key{kid: key},
+		SigningKeyID:              kid,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := issuer.GenerateAccessToken("user-4", "member")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
@@ -0,0 +1,40 @@
+package httpprotocol
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	grpcprotocol "github.com/purnasatria/library-management/pkg/protocol/grpc"
+)
+
+// RequestIDHeader carries the ID of a request, the gateway forwards it to the gRPC services as
+// x-request-id metadata and returns it in the response
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware gives every request an ID, the client's X-Request-ID if it is a sane
+// one or a new one otherwise, returns it in the X-Request-ID response header and logs it on
+// every line logged with the request's context through log.Ctx
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !grpcprotocol.ValidRequestID(id) {
+			id = uuid.New().String()
+			r.Header.Set(RequestIDHeader, id)
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := log.With().Str("request_id", id).Logger().WithContext(r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// httpError replies with a plain text error naming the request ID, which the client can quote
+// to find the request in the logs
+func httpError(w http.ResponseWriter, r *http.Request, message string, code int) {
+	if id := r.Header.Get(RequestIDHeader); id != "" {
+		message += " (request ID " + id + ")"
+	}
+	http.Error(w, message, code)
+}
@@ -0,0 +1,106 @@
+package httpprotocol
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	pb_auth "github.com/purnasatria/library-management/api/gen/auth"
+)
+
+// Revocations keeps the access token revocations of the auth service, so locally verified
+// tokens can be checked without a call per token. A revocation takes effect here with the next
+// refresh.
+type Revocations struct {
+	client   pb_auth.AuthServiceClient
+	interval time.Duration
+
+	mu     sync.RWMutex
+	loaded bool
+	tokens map[string]struct{}
+	users  map[string]time.Time
+}
+
+// NewRevocations fetches the revocations and refreshes them every interval until ctx is done
+func NewRevocations(ctx context.Context, client pb_auth.AuthServiceClient, interval time.Duration) *Revocations {
+	r := &Revocations{client: client, interval: interval}
+
+	if err := r.refresh(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to fetch token revocations, retrying later")
+	}
+	go r.run(ctx)
+
+	return r
+}
+
+// Loaded reports whether the revocations have been fetched at least once
+func (r *Revocations) Loaded() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.loaded
+}
+
+// IsRevoked reports whether the token with the given ID, issued to userID at issuedAt, was
+// revoked
+func (r *Revocations) IsRevoked(tokenID, userID string, issuedAt time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.tokens[tokenID]; ok {
+		return true
+	}
+	// The zero time revokes every token of the user
+	before, ok := r.users[userID]
+	return ok && (before.IsZero() || !issuedAt.After(before))
+}
+
+func (r *Revocations) run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.refresh(ctx); err != nil {
+				log.Warn().Err(err).Msg("Failed to refresh token revocations")
+			}
+		}
+	}
+}
+
+func (r *Revocations) refresh(ctx context.Context) error {
+	resp, err := r.client.ListRevocations(ctx, &pb_auth.ListRevocationsRequest{})
+	if err != nil {
+		return err
+	}
+
+	tokens := make(map[string]struct{}, len(resp.TokenIds))
+	for _, id := range resp.TokenIds {
+		tokens[id] = struct{}{}
+	}
+	users := make(map[string]time.Time, len(resp.Users))
+	for _, user := range resp.Users {
+		var before time.Time
+		if user.RevokedBefore != nil {
+			before = user.RevokedBefore.AsTime()
+		}
+		users[user.UserId] = before
+	}
+
+	r.mu.Lock()
+	r.loaded = true
+	r.tokens = tokens
+	r.users = users
+	r.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package httpprotocol
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// maxCachedTokens bounds the cache, expired entries are dropped once it is full
+const maxCachedTokens = 10000
+
+// verification is the result of verifying a token, locally or by the auth service
+type verification struct {
+	valid  bool
+	userID string
+	role   string
+	// local verifications still have to be checked against the revocations
+	local     bool
+	tokenID   string
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// tokenCache keeps verifications for a short time, keyed by the hash of the token
+type tokenCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]verification
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{entries: make(map[[sha256.Size]byte]verification)}
+}
+
+func (c *tokenCache) get(token string) (verification, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sha256.Sum256([]byte(token))
+	v, ok := c.entries[key]
+	if !ok {
+		return verification{}, false
+	}
+	if time.Now().After(v.expiresAt) {
+		delete(c.entries, key)
+		return verification{}, false
+	}
+	return v, true
+}
+
+func (c *tokenCache) set(token string, v verification) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedTokens {
+		now := time.Now()
+		for key, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= maxCachedTokens {
+			c.entries = make(map[[sha256.Size]byte]verification)
+		}
+	}
+
+	c.entries[sha256.Sum256([]byte(token))] = v
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Body fields that identify the resource rather than update it
+var unmaskedFields = map[string]bool{"id": true, "version": true}
+
+// patchFieldMask derives the update mask of PATCH requests that do not send one from the
+// top-level fields of their JSON body, so only the fields the client sent are updated.
+// grpc-gateway only does this for bindings whose body is a single field, not "*".
+func patchFieldMask(gwmux *runtime.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.Body == nil {
+			gwmux.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			patchError(gwmux, w, r, status.Error(codes.InvalidArgument, "failed to read request body"))
+			return
+		}
+
+		body, err = withUpdateMask(body)
+		if err != nil {
+			patchError(gwmux, w, r, err)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		gwmux.ServeHTTP(w, r)
+	})
+}
+
+func withUpdateMask(body []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		// Leave malformed bodies to the gateway, which reports them in its own format
+		return body, nil
+	}
+
+	if _, ok := fields["updateMask"]; ok {
+		return body, nil
+	}
+	if _, ok := fields["update_mask"]; ok {
+		return body, nil
+	}
+
+	var paths []string
+	for key := range fields {
+		if !unmaskedFields[key] {
+			paths = append(paths, lowerCamel(key))
+		}
+	}
+	// An empty mask means a full update, which is never what an empty PATCH asks for
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no fields to update")
+	}
+	sort.Strings(paths)
+
+	mask, err := json.Marshal(strings.Join(paths, ","))
+	if err != nil {
+		return nil, err
+	}
+	fields["updateMask"] = mask
+
+	return json.Marshal(fields)
+}
+
+func patchError(gwmux *runtime.ServeMux, w http.ResponseWriter, r *http.Request, err error) {
+	_, outbound := runtime.MarshalerForRequest(gwmux, r)
+	runtime.HTTPError(r.Context(), gwmux, outbound, w, r, err)
+}
+
+// lowerCamel converts proto field names (e.g. "category_ids") to the JSON names used in
+// field mask paths (e.g. "categoryIds"); names that are already camel case are unchanged
+func lowerCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
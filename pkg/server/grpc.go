@@ -1,34 +1,105 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
 
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type GRPCServerConfig struct {
 	Port              string
 	RegisterService   func(*grpc.Server)
 	UnaryInterceptors []grpc.UnaryServerInterceptor
+	// StreamInterceptors are the equivalents of UnaryInterceptors for streaming calls, which
+	// the unary ones do not see
+	StreamInterceptors []grpc.StreamServerInterceptor
+	// Credentials secure the connections, nil serves plaintext
+	Credentials credentials.TransportCredentials
+	// Readiness is reported by the grpc.health.v1 service, nil reports serving until shutdown
+	Readiness *Readiness
 }
 
-func RunGRPCServer(cfg GRPCServerConfig) {
+// GRPCServer is a running gRPC server
+type GRPCServer struct {
+	server *grpc.Server
+	errs   chan error
+}
+
+// RunGRPCServer starts serving gRPC in the background
+func RunGRPCServer(cfg GRPCServerConfig) (*GRPCServer, error) {
 	lis, err := net.Listen("tcp", cfg.Port)
 	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to listen on port: %v", cfg.Port)
+		return nil, fmt.Errorf("listen on %s: %w", cfg.Port, err)
 	}
 
-	opts := []grpc.ServerOption{}
+	// Calls join the trace of the caller, health checks are too frequent to be worth tracing
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithFilter(filters.Not(filters.ServiceName(healthpb.Health_ServiceDesc.ServiceName))),
+		)),
+	}
+	if cfg.Credentials != nil {
+		opts = append(opts, grpc.Creds(cfg.Credentials))
+	}
 	if len(cfg.UnaryInterceptors) > 0 {
 		opts = append(opts, grpc.ChainUnaryInterceptor(cfg.UnaryInterceptors...))
 	}
+	if len(cfg.StreamInterceptors) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(cfg.StreamInterceptors...))
+	}
 
 	s := grpc.NewServer(opts...)
 	cfg.RegisterService(s)
 
+	readiness := cfg.Readiness
+	if readiness == nil {
+		readiness = NewReadiness(nil)
+	}
+	healthpb.RegisterHealthServer(s, &healthServer{readiness: readiness, services: s.GetServiceInfo()})
+
 	log.Info().Msgf("Starting gRPC server on %s", cfg.Port)
-	if err := s.Serve(lis); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve gRPC")
+	errs := make(chan error, 1)
+	go func() {
+		if err := s.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			errs <- fmt.Errorf("serve gRPC: %w", err)
+		}
+	}()
+
+	return &GRPCServer{server: s, errs: errs}, nil
+}
+
+// Err receives the error the server stopped serving with, nothing when it is shut down. It
+// is nil for a nil server, so a server that is not running never fails.
+func (s *GRPCServer) Err() <-chan error {
+	if s == nil {
+		return nil
+	}
+	return s.errs
+}
+
+// Shutdown stops accepting calls and waits for the running ones to finish, cancelling those
+// still running once ctx is done
+func (s *GRPCServer) Shutdown(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Info().Msg("gRPC server stopped")
+	case <-ctx.Done():
+		log.Warn().Msg("gRPC calls still running after the drain timeout, cancelling them")
+		s.server.Stop()
+		<-stopped
 	}
 }
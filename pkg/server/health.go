@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HealthPath answers whether the service is alive, whatever the state of its dependencies
+	HealthPath = "/healthz"
+	// ReadinessPath answers whether the service can take requests
+	ReadinessPath = "/readyz"
+)
+
+// checkTimeout bounds each readiness check, so a hanging dependency fails the check instead
+// of the probe
+const checkTimeout = 2 * time.Second
+
+// Check reports whether a dependency of the service works, e.g. (*sql.DB).PingContext
+type Check func(ctx context.Context) error
+
+// ConnCheck checks that a connection to another service is established or can be
+func ConnCheck(conn *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		conn.Connect()
+		for {
+			state := conn.GetState()
+			if state == connectivity.Ready {
+				return nil
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("connection is %s", state)
+			}
+		}
+	}
+}
+
+// Readiness answers whether the service can take requests: its checks pass and it is not
+// shutting down. It is served at ReadinessPath and as the grpc.health.v1 service.
+type Readiness struct {
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+// NewReadiness creates the readiness of a service with checks of its dependencies, by name
+func NewReadiness(checks map[string]Check) *Readiness {
+	return &Readiness{checks: checks}
+}
+
+// fail makes the service report that it is not ready for good
+func (r *Readiness) fail() {
+	r.shuttingDown.Store(true)
+}
+
+// check runs the checks at once and returns the failures, by name
+func (r *Readiness) check(ctx context.Context) map[string]string {
+	failures := make(map[string]string)
+	if r.shuttingDown.Load() {
+		failures["shutdown"] = "shutting down"
+		return failures
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range r.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			if err := check(ctx); err != nil {
+				mu.Lock()
+				failures[name] = err.Error()
+				mu.Unlock()
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return failures
+}
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	resp := readinessResponse{Status: "ok", Checks: make(map[string]string)}
+	for name := range r.checks {
+		resp.Checks[name] = "ok"
+	}
+
+	code := http.StatusOK
+	if failures := r.check(req.Context()); len(failures) > 0 {
+		resp.Status = "unavailable"
+		for name, failure := range failures {
+			resp.Checks[name] = failure
+		}
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// healthHandler answers liveness probes, the service is alive as long as it answers
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte("ok\n"))
+}
+
+// healthServer is the grpc.health.v1 service, it reports the readiness of the whole server
+// and of each of its services
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	readiness *Readiness
+	services  map[string]grpc.ServiceInfo
+}
+
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if _, ok := h.services[req.Service]; req.Service != "" && !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.Service)
+	}
+
+	if failures := h.readiness.check(ctx); len(failures) > 0 {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
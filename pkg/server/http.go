@@ -2,59 +2,179 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/textproto"
+	"strings"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	httpprotocol "github.com/purnasatria/library-management/pkg/protocol/http"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type HTTPServerConfig struct {
 	Port            string
 	GRPCPort        string
 	RegisterGateway func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error
+	// GRPCCredentials secure the gateway's connection to the gRPC server, nil connects in
+	// plaintext
+	GRPCCredentials credentials.TransportCredentials
 	Middlewares     []func(http.Handler) http.Handler
+	// Handlers are served next to the gateway, keyed by path pattern
+	Handlers map[string]http.Handler
+	// Readiness is served at ReadinessPath, nil serves none
+	Readiness       *Readiness
 	SwaggerUIDir    string
 	SwaggerJSONPath string
 }
 
-func RunHTTPServer(ctx context.Context, cfg HTTPServerConfig) {
+// HTTPServer is a running HTTP server
+type HTTPServer struct {
+	server *http.Server
+	errs   chan error
+}
+
+// RunHTTPServer starts serving the gateway in the background
+func RunHTTPServer(ctx context.Context, cfg HTTPServerConfig) (*HTTPServer, error) {
 	// Setup gRPC-gateway mux
 	gwmux := runtime.NewServeMux(
-		runtime.WithErrorHandler(runtime.DefaultHTTPErrorHandler),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithMiddlewares(recordRoute),
 		runtime.WithMetadata(func(ctx context.Context, req *http.Request) metadata.MD {
 			return metadata.Pairs("grpc-status-details-bin", req.Header.Get("Grpc-Metadata-Grpc-Status-Details-Bin"))
 		}),
 	)
 
 	// Register gateway
-	optsgrpc := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-	err := cfg.RegisterGateway(ctx, gwmux, cfg.GRPCPort, optsgrpc)
+	creds := cfg.GRPCCredentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	// The server's certificate is verified against the host name, a port alone has none
+	endpoint := cfg.GRPCPort
+	if strings.HasPrefix(endpoint, ":") {
+		endpoint = "localhost" + endpoint
+	}
+	optsgrpc := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	err := cfg.RegisterGateway(ctx, gwmux, endpoint, optsgrpc)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to register gateway")
+		return nil, fmt.Errorf("register gateway: %w", err)
 	}
 
 	// Create main mux and add gRPC-gateway
 	mux := http.NewServeMux()
-	mux.Handle("/", gwmux)
+	mux.Handle("/", patchFieldMask(gwmux))
+	for pattern, handler := range cfg.Handlers {
+		mux.Handle(pattern, handler)
+	}
+	mux.HandleFunc(HealthPath, healthHandler)
+	mux.Handle(MetricsPath, metricsHandler())
+	if cfg.Readiness != nil {
+		mux.Handle(ReadinessPath, cfg.Readiness)
+	}
 
 	// Setup Swagger UI
 	setupSwaggerUI(mux, cfg.SwaggerUIDir, cfg.SwaggerJSONPath)
 
-	// Apply middlewares
-	handler := applyMiddlewares(mux, cfg.Middlewares)
+	// Apply middlewares, requests they reject are measured too
+	handler := instrumentHTTP(mux, applyMiddlewares(mux, cfg.Middlewares))
+	handler = httpprotocol.AccessLogMiddleware(HealthPath, ReadinessPath, MetricsPath)(handler)
+	handler = httpprotocol.RequestIDMiddleware(handler)
+	handler = traceHTTP(handler)
 
 	// Start server
+	lis, err := net.Listen("tcp", cfg.Port)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", cfg.Port, err)
+	}
+	srv := &http.Server{Handler: handler}
+
 	log.Info().Msgf("Starting HTTP server on %s", cfg.Port)
 	log.Info().Msgf("API is served at http://localhost%s", cfg.Port)
 	log.Info().Msgf("Swagger UI is served at http://localhost%s/docs", cfg.Port)
 
-	if err := http.ListenAndServe(cfg.Port, handler); err != nil {
-		log.Fatal().Err(err).Msg("Failed to serve HTTP")
+	errs := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- fmt.Errorf("serve HTTP: %w", err)
+		}
+	}()
+
+	return &HTTPServer{server: srv, errs: errs}, nil
+}
+
+// Err receives the error the server stopped serving with, nothing when it is shut down. It
+// is nil for a nil server, so a server that is not running never fails.
+func (s *HTTPServer) Err() <-chan error {
+	if s == nil {
+		return nil
+	}
+	return s.errs
+}
+
+// Shutdown stops accepting requests and waits for the running ones to finish, closing the
+// connections of those still running once ctx is done
+func (s *HTTPServer) Shutdown(ctx context.Context) {
+	if err := s.server.Shutdown(ctx); err != nil {
+		log.Warn().Err(err).Msg("HTTP requests still running after the drain timeout, closing them")
+		s.server.Close()
+		return
+	}
+	log.Info().Msg("HTTP server stopped")
+}
+
+// errorHandler reports version conflicts (Aborted) as 412 Precondition Failed, the status
+// HTTP clients expect for a stale If-Match, and defers to the default handler otherwise. The
+// request ID is added to the error's details as a google.rpc.RequestInfo.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if id := r.Header.Get(httpprotocol.RequestIDHeader); id != "" {
+		if st, ok := status.FromError(err); ok {
+			if withID, detailErr := st.WithDetails(&errdetails.RequestInfo{RequestId: id}); detailErr == nil {
+				err = withID.Err()
+			}
+		}
+	}
+	if status.Code(err) == codes.Aborted {
+		err = &runtime.HTTPStatusError{HTTPStatus: http.StatusPreconditionFailed, Err: err}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// incomingHeaderMatcher forwards X-Request-ID so the services log it and audit events can be
+// traced back to the request
+func incomingHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == "X-Request-Id" {
+		return "x-request-id", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher sends the ETag set by the services as a plain header. The request ID
+// the services return is already in the X-Request-ID header.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case "etag":
+		return "ETag", true
+	case "x-request-id":
+		return "", false
 	}
+	return runtime.MetadataHeaderPrefix + key, true
 }
 
 func setupSwaggerUI(mux *http.ServeMux, swaggerUIDir, swaggerJSONPath string) {
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MetricsPath serves the service's metrics in the Prometheus format
+const MetricsPath = "/metrics"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests served, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to serve HTTP requests, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+func metricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// routeKey holds the route of a request, set by recordRoute once the gateway matched one
+type routeKey struct{}
+
+// recordRoute records the path pattern of the gateway route that matched, e.g.
+// "/api/v1/books/{id=*}", so requests are counted and traced by route rather than by path
+func recordRoute(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+			if route, ok := r.Context().Value(routeKey{}).(*string); ok {
+				*route = pattern.String()
+			}
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + pattern.String())
+			span.SetAttributes(semconv.HTTPRoute(pattern.String()))
+		}
+		next(w, r, pathParams)
+	}
+}
+
+// instrumentHTTP counts the requests by route and status code and records how long they take.
+// Requests the gateway did not route are counted by the pattern of mux they matched.
+func instrumentHTTP(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		route := new(string)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), routeKey{}, route)))
+
+		if *route == "" {
+			_, *route = mux.Handler(r)
+		}
+		httpRequests.WithLabelValues(r.Method, *route, strconv.Itoa(sw.status)).Inc()
+		httpRequestSeconds.WithLabelValues(r.Method, *route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusWriter records the status code of a response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
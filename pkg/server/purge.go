@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type PurgeJobConfig struct {
+	// Name of the purged records, used in logs
+	Name string
+	// Retention is how long deleted records are kept, zero disables the job
+	Retention time.Duration
+	Interval  time.Duration
+	// Purge permanently removes the records deleted before the given time
+	Purge func(ctx context.Context, before time.Time) (int, error)
+}
+
+// RunPurgeJob permanently removes soft-deleted records once they are older than the retention
+// period, checking every interval until ctx is done
+func RunPurgeJob(ctx context.Context, cfg PurgeJobConfig) {
+	if cfg.Retention <= 0 || cfg.Interval <= 0 {
+		log.Info().Msgf("Purging deleted %s is disabled", cfg.Name)
+		return
+	}
+
+	log.Info().Msgf("Purging deleted %s older than %s every %s", cfg.Name, cfg.Retention, cfg.Interval)
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := cfg.Purge(ctx, time.Now().Add(-cfg.Retention))
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to purge deleted %s", cfg.Name)
+		} else if purged > 0 {
+			log.Info().Int("count", purged).Msgf("Purged deleted %s", cfg.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ShutdownConfig says how a service shuts down, servers that are not running are nil
+type ShutdownConfig struct {
+	Readiness  *Readiness
+	HTTPServer *HTTPServer
+	GRPCServer *GRPCServer
+	// ReadinessDelay is how long the service keeps serving after failing readiness, so load
+	// balancers stop sending it requests before it stops accepting them
+	ReadinessDelay time.Duration
+	// DrainTimeout is how long running requests get to finish before they are cut off
+	DrainTimeout time.Duration
+}
+
+// Shutdown fails readiness, then stops the HTTP gateway and the gRPC server once their
+// running requests are done. The gateway goes first, its requests need the gRPC server.
+func Shutdown(cfg ShutdownConfig) {
+	if cfg.Readiness != nil {
+		cfg.Readiness.fail()
+		log.Info().Dur("delay", cfg.ReadinessDelay).Msg("Readiness failed, waiting for load balancers")
+		time.Sleep(cfg.ReadinessDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancel()
+
+	if cfg.HTTPServer != nil {
+		cfg.HTTPServer.Shutdown(ctx)
+	}
+	if cfg.GRPCServer != nil {
+		cfg.GRPCServer.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// untracedPaths are requested by probes and scrapers, too often to be worth tracing
+var untracedPaths = map[string]bool{
+	HealthPath:    true,
+	ReadinessPath: true,
+	MetricsPath:   true,
+}
+
+// traceHTTP starts a span for each request, joining the trace of the caller. The span is named
+// after the gateway route once recordRoute matched one.
+func traceHTTP(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "HTTP",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		}),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !untracedPaths[r.URL.Path]
+		}),
+	)
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporters of Config.Exporter
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// shutdownTimeout bounds the export of the spans left when the service stops
+const shutdownTimeout = 5 * time.Second
+
+type Config struct {
+	ServiceName string
+	// Exporter is where spans are sent: ExporterOTLP to the collector at the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT, ExporterStdout as JSON to File for local use, or
+	// ExporterNone (also empty) to record none
+	Exporter string
+	// File is where ExporterStdout appends spans, the standard output if empty. The logs go to
+	// the standard error, but where both streams end up in one log a file keeps them apart.
+	File string
+	// SampleRatio is the share of the traces started by the service that are recorded, traces
+	// started by a caller are recorded if the caller recorded them
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator. Trace
+// context is propagated even without an exporter, so a service in the middle of a call chain
+// does not break the traces of the others. The returned function exports the remaining
+// spans, call it as the service stops.
+func Setup(ctx context.Context, cfg Config) (func(), error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return func() {}, nil
+	case ExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx)
+	case ExporterStdout:
+		file = os.Stdout
+		if cfg.File != "" {
+			file, err = os.OpenFile(cfg.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("open trace file: %w", err)
+			}
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Warn().Err(err).Msg("Failed to export the remaining spans")
+		}
+		if file != nil && file != os.Stdout {
+			file.Close()
+		}
+	}, nil
+}